@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Behaviour identifies the lathos behaviour an error code is raised with.
+type Behaviour string
+
+// Behaviours supported by the catalog.
+const (
+	NotFound         Behaviour = "not_found"
+	Duplicate        Behaviour = "duplicate"
+	Conflict         Behaviour = "conflict"
+	BadRequest       Behaviour = "bad_request"
+	NotAuthenticated Behaviour = "not_authenticated"
+	NotAuthorised    Behaviour = "not_authorised"
+	CannotProcess    Behaviour = "cannot_process"
+	TooManyRequests  Behaviour = "too_many_requests"
+	Unavailable      Behaviour = "unavailable"
+	Timeout          Behaviour = "timeout"
+	Canceled         Behaviour = "canceled"
+	Internal         Behaviour = "internal"
+	Retryable        Behaviour = "retryable"
+)
+
+// statusClientClosedRequest is the non standard status, first used by nginx,
+// for a request the client cancelled.
+const statusClientClosedRequest = 499
+
+// behaviourInfo holds the defaults for a behaviour and how to build it.
+type behaviourInfo struct {
+	status int
+	title  string
+	client func(code, detail string) lathos.ClientError
+}
+
+// behaviourInfos is a read only lookup of each Behaviour.
+var behaviourInfos = map[Behaviour]behaviourInfo{ //nolint:gochecknoglobals // read only lookup table.
+	NotFound: {status: http.StatusNotFound, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrNotFound(code, detail)
+	}},
+	Duplicate: {status: http.StatusConflict, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrDuplicate(code, detail)
+	}},
+	Conflict: {status: http.StatusConflict, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrConflict(code, detail)
+	}},
+	BadRequest: {status: http.StatusBadRequest, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrBadRequest(code, detail)
+	}},
+	NotAuthenticated: {status: http.StatusUnauthorized, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrNotAuthenticated(code, detail)
+	}},
+	NotAuthorised: {status: http.StatusForbidden, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrNotAuthorised(code, detail)
+	}},
+	CannotProcess: {status: http.StatusUnprocessableEntity, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrUnprocessable(code, detail)
+	}},
+	TooManyRequests: {status: http.StatusTooManyRequests, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrTooManyRequests(code, detail)
+	}},
+	Unavailable: {status: http.StatusServiceUnavailable, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrNotAvailable(code, detail)
+	}},
+	Timeout: {status: http.StatusGatewayTimeout, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrTimeout(code, detail)
+	}},
+	Canceled: {status: statusClientClosedRequest, client: func(code, detail string) lathos.ClientError {
+		return errs.NewErrCanceled(code, detail)
+	}},
+	Internal:  {status: http.StatusInternalServerError, title: "Internal error"},
+	Retryable: {status: http.StatusServiceUnavailable, title: "Retryable error occurred"},
+}
+
+// Valid returns true if b is a known behaviour.
+func (b Behaviour) Valid() bool {
+	_, ok := behaviourInfos[b]
+	return ok
+}
+
+// Client returns true if errors with this behaviour are client errors.
+func (b Behaviour) Client() bool {
+	return behaviourInfos[b].client != nil
+}
+
+// Status returns the default HTTP status code for the behaviour.
+func (b Behaviour) Status() int {
+	return behaviourInfos[b].status
+}
+
+// Title returns the default title for the behaviour, this matches
+// the title of the errs type for client behaviours.
+func (b Behaviour) Title() string {
+	info := behaviourInfos[b]
+	if info.client != nil {
+		return info.client("", "").Title()
+	}
+	return info.title
+}
+
+// Behaviours returns every behaviour supported by the catalog in alphabetical order.
+func Behaviours() []Behaviour {
+	bb := make([]Behaviour, 0, len(behaviourInfos))
+	for b := range behaviourInfos {
+		bb = append(bb, b)
+	}
+	sort.Slice(bb, func(i, j int) bool {
+		return bb[i] < bb[j]
+	})
+	return bb
+}
+
+// BehaviourOf will return the Behaviour of err by checking the lathos behaviours it implements.
+// Errors that are not client errors, or don't implement a known behaviour, are Internal.
+func BehaviourOf(err error) Behaviour {
+	switch {
+	case lathos.IsNotFound(err):
+		return NotFound
+	case lathos.IsDuplicate(err):
+		return Duplicate
+	case lathos.IsConflict(err):
+		return Conflict
+	case lathos.IsBadRequest(err):
+		return BadRequest
+	case lathos.IsNotAuthenticated(err):
+		return NotAuthenticated
+	case lathos.IsNotAuthorised(err):
+		return NotAuthorised
+	case lathos.IsCannotProcess(err):
+		return CannotProcess
+	case lathos.IsTooManyRequests(err):
+		return TooManyRequests
+	case lathos.IsTimeout(err):
+		return Timeout
+	case lathos.IsCanceled(err):
+		return Canceled
+	case lathos.IsUnavailable(err):
+		return Unavailable
+	case lathos.IsRetryable(err):
+		return Retryable
+	}
+	return Internal
+}
@@ -0,0 +1,197 @@
+// Package catalog contains a registry of error codes.
+//
+// Each code is registered once along with the behaviour it is raised with, its title,
+// a default detail and documentation. Errors can then be created from the catalog
+// by code meaning codes are used consistently and can be published to clients.
+//
+//	cat := catalog.New()
+//	cat.MustRegister(catalog.Entry{
+//		Code:      "U404",
+//		Behaviour: catalog.NotFound,
+//		Title:     "User not found",
+//		Detail:    "user %s not found",
+//	})
+//	err := cat.New("U404", userID)
+package catalog
+
+import (
+	"sort"
+	"sync"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Entry describes a single error code.
+type Entry struct {
+	// Code is the unique code for the error, ie U404.
+	Code string `json:"code" yaml:"code"`
+	// Behaviour is the lathos behaviour the error is raised with.
+	Behaviour Behaviour `json:"behaviour" yaml:"behaviour"`
+	// Title is the title for all errors with this code, if empty the
+	// default title for the behaviour is used.
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+	// Detail is the default detail for the error, it is used as a format
+	// string with the arguments supplied when creating the error.
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	// Status is the HTTP status returned for the error, if zero the
+	// default status for the behaviour is used.
+	Status int `json:"status,omitempty" yaml:"status,omitempty"`
+	// Docs is documentation to publish for the error code.
+	Docs string `json:"docs,omitempty" yaml:"docs,omitempty"`
+	// Causes lists the likely causes of the error.
+	Causes []string `json:"causes,omitempty" yaml:"causes,omitempty"`
+	// Remediation describes how a client can resolve the error.
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+	// Name is used by lathos-gen to name the generated code for the
+	// entry, ie UserNotFound will generate ErrUserNotFound.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Params describes the arguments used to format Detail, in order.
+	Params []Param `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// Param is a named and typed argument used to format an entry's Detail.
+type Param struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+}
+
+// Catalog is a registry of error codes, it is safe for concurrent use.
+type Catalog struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+// New will create and return an empty Catalog.
+func New() *Catalog {
+	return &Catalog{
+		entries: map[string]*Entry{},
+	}
+}
+
+// Register will add entries to the catalog. If any entry is invalid or its
+// code has already been registered an error is returned and no entries are added.
+//
+// Entries without a Title or Status will have the defaults for their Behaviour set.
+// If an entry declares Params they must match the verbs used in its Detail.
+func (c *Catalog) Register(entries ...Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if err := e.validate(); err != nil {
+			return err
+		}
+		_, registered := c.entries[e.Code]
+		if _, ok := seen[e.Code]; ok || registered {
+			return errs.NewErrDuplicatef("", "error code %s is already registered", e.Code)
+		}
+		seen[e.Code] = struct{}{}
+	}
+	for _, e := range entries {
+		e := e
+		if e.Title == "" {
+			e.Title = e.Behaviour.Title()
+		}
+		if e.Status == 0 {
+			e.Status = e.Behaviour.Status()
+		}
+		c.entries[e.Code] = &e
+	}
+	return nil
+}
+
+// MustRegister will add entries to the catalog and panic if any
+// are invalid or duplicated. This is intended to be used at startup
+// or in package init functions.
+func (c *Catalog) MustRegister(entries ...Entry) {
+	if err := c.Register(entries...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup will return the entry for code, false is returned if it
+// has not been registered.
+func (c *Catalog) Lookup(code string) (Entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[code]
+	if !ok {
+		return Entry{}, false
+	}
+	return *e, true
+}
+
+// Entries will return every registered entry ordered by code.
+func (c *Catalog) Entries() []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ee := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		ee = append(ee, *e)
+	}
+	sort.Slice(ee, func(i, j int) bool {
+		return ee[i].Code < ee[j].Code
+	})
+	return ee
+}
+
+// New will create an error for code using its registered behaviour, title and
+// detail, args are used to format the detail.
+//
+// Client behaviours return an Error wrapping the errs type for the behaviour,
+// internal behaviours return the errs type directly.
+// If code is not registered, or the number of args doesn't match the verbs in
+// the detail, an internal error is returned.
+func (c *Catalog) New(code string, args ...interface{}) error {
+	c.mu.RLock()
+	e, ok := c.entries[code]
+	c.mu.RUnlock()
+	if !ok {
+		return errs.NewErrInternal(pkgerrs.Errorf("error code %s is not registered", code), code)
+	}
+	return e.new(args...)
+}
+
+// validate checks an entry has the minimum fields required.
+func (e Entry) validate() error {
+	if e.Code == "" {
+		return errs.NewErrBadRequest("", "error code cannot be empty")
+	}
+	if !e.Behaviour.Valid() {
+		return errs.NewErrBadRequestf("", "error code %s has unknown behaviour %q", e.Code, e.Behaviour)
+	}
+	if n := argCount(e.Detail); len(e.Params) > 0 && n != len(e.Params) {
+		return errs.NewErrBadRequestf("", "error code %s declares %d params but its detail uses %d", e.Code, len(e.Params), n)
+	}
+	return nil
+}
+
+// defaultCatalog is used by the package level functions.
+var defaultCatalog = New() //nolint:gochecknoglobals // shared default catalog.
+
+// Default returns the catalog used by the package level functions.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// Register will add entries to the default catalog, see Catalog.Register.
+func Register(entries ...Entry) error {
+	return defaultCatalog.Register(entries...)
+}
+
+// MustRegister will add entries to the default catalog, panicking on error, see Catalog.MustRegister.
+func MustRegister(entries ...Entry) {
+	defaultCatalog.MustRegister(entries...)
+}
+
+// Lookup will return the entry for code from the default catalog, see Catalog.Lookup.
+func Lookup(code string) (Entry, bool) {
+	return defaultCatalog.Lookup(code)
+}
+
+// NewError will create an error for code from the default catalog, see Catalog.New.
+func NewError(code string, args ...interface{}) error {
+	return defaultCatalog.New(code, args...)
+}
@@ -0,0 +1,260 @@
+package catalog
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestCatalog_Register(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		existing []Entry
+		entries  []Entry
+		expErr   func(error) bool
+	}{
+		"valid entries should register": {
+			entries: []Entry{
+				{Code: "U404", Behaviour: NotFound},
+				{Code: "U409", Behaviour: Duplicate},
+			},
+		},
+		"empty code should error": {
+			entries: []Entry{{Behaviour: NotFound}},
+			expErr:  lathos.IsBadRequest,
+		},
+		"unknown behaviour should error": {
+			entries: []Entry{{Code: "U404", Behaviour: "missing"}},
+			expErr:  lathos.IsBadRequest,
+		},
+		"code already registered should error": {
+			existing: []Entry{{Code: "U404", Behaviour: NotFound}},
+			entries:  []Entry{{Code: "U404", Behaviour: Conflict}},
+			expErr:   lathos.IsDuplicate,
+		},
+		"params not matching the detail should error": {
+			entries: []Entry{{Code: "U404", Behaviour: NotFound, Detail: "user %s not found",
+				Params: []Param{{Name: "org", Type: "string"}, {Name: "userID", Type: "string"}}}},
+			expErr: lathos.IsBadRequest,
+		},
+		"params matching an indexed detail should register": {
+			entries: []Entry{{Code: "U404", Behaviour: NotFound, Detail: "user %s not found, is %[1]q right?",
+				Params: []Param{{Name: "userID", Type: "string"}}}},
+		},
+		"code repeated in entries should error": {
+			entries: []Entry{
+				{Code: "U404", Behaviour: NotFound},
+				{Code: "U404", Behaviour: NotFound},
+			},
+			expErr: lathos.IsDuplicate,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			c := New()
+			c.MustRegister(test.existing...)
+			err := c.Register(test.entries...)
+			if test.expErr != nil {
+				is.True(test.expErr(err))
+				is.Equal(len(c.Entries()), len(test.existing))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(len(c.Entries()), len(test.entries))
+		})
+	}
+}
+
+func TestCatalog_Lookup(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	c := New()
+	c.MustRegister(
+		Entry{Code: "U404", Behaviour: NotFound},
+		Entry{Code: "P402", Behaviour: CannotProcess, Title: "Payment required", Status: http.StatusPaymentRequired},
+	)
+
+	e, ok := c.Lookup("U404")
+	is.True(ok)
+	is.Equal(e.Title, "Not found")
+	is.Equal(e.Status, http.StatusNotFound)
+
+	e, ok = c.Lookup("P402")
+	is.True(ok)
+	is.Equal(e.Title, "Payment required")
+	is.Equal(e.Status, http.StatusPaymentRequired)
+
+	_, ok = c.Lookup("X001")
+	is.True(!ok)
+}
+
+func TestCatalog_New(t *testing.T) {
+	t.Parallel()
+	c := New()
+	c.MustRegister(
+		Entry{Code: "U404", Behaviour: NotFound, Title: "User not found", Detail: "user %s not found"},
+		Entry{Code: "D001", Behaviour: Duplicate, Detail: "item already exists"},
+		Entry{Code: "I001", Behaviour: Internal, Detail: "database %s unreachable"},
+		Entry{Code: "R001", Behaviour: Retryable, Detail: "try again"},
+	)
+	tests := map[string]struct {
+		code     string
+		args     []interface{}
+		expCheck func(error) bool
+		expCode  string
+		expErr   string
+	}{
+		"client error should use catalog title and format detail": {
+			code:     "U404",
+			args:     []interface{}{"123"},
+			expCheck: lathos.IsNotFound,
+			expCode:  "U404",
+			expErr:   "User not found: user 123 not found",
+		},
+		"client error without args should use detail": {
+			code:     "D001",
+			expCheck: lathos.IsDuplicate,
+			expCode:  "D001",
+			expErr:   "Item already exists: item already exists",
+		},
+		"internal error should be created": {
+			code:     "I001",
+			args:     []interface{}{"users"},
+			expCheck: lathos.IsInternalError,
+			expErr:   "Internal error: database users unreachable",
+		},
+		"retryable error should be created": {
+			code:     "R001",
+			expCheck: lathos.IsRetryable,
+			expErr:   "Retryable error occurred: try again",
+		},
+		"missing args should return internal error": {
+			code:     "U404",
+			expCheck: lathos.IsInternalError,
+			expErr:   "error code U404 expects 1 args but got 0",
+		},
+		"extra args should return internal error": {
+			code:     "D001",
+			args:     []interface{}{"123"},
+			expCheck: lathos.IsInternalError,
+			expErr:   "error code D001 expects 0 args but got 1",
+		},
+		"unregistered code should return internal error": {
+			code:     "X001",
+			expCheck: lathos.IsInternalError,
+			expErr:   "error code X001 is not registered",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := c.New(test.code, test.args...)
+			is.True(test.expCheck(err))
+			is.Equal(err.Error(), test.expErr)
+			var ce lathos.ClientError
+			if errors.As(err, &ce) {
+				is.Equal(ce.Code(), test.expCode)
+			}
+		})
+	}
+}
+
+func TestCatalog_New_Cause(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	c := New()
+	c.MustRegister(Entry{Code: "I001", Behaviour: Internal, Title: "Database unreachable", Detail: "database %s unreachable: %v"})
+	dbErr := errors.New("connection refused")
+
+	err := c.New("I001", "users", dbErr)
+	is.Equal(err.Error(), "Database unreachable: database users unreachable: connection refused")
+	var ie interface{ Cause() error }
+	is.True(errors.As(err, &ie))
+	is.True(errors.Is(ie.Cause(), dbErr)) // the error argument is kept as the cause
+}
+
+func TestEntry_ExampleArgs(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		entry     Entry
+		expDetail string
+	}{
+		"detail without verbs should have no args": {
+			entry:     Entry{Code: "U404", Behaviour: NotFound, Detail: "user not found"},
+			expDetail: "user not found",
+		},
+		"params should be named or given a sample value": {
+			entry: Entry{Code: "U429", Behaviour: TooManyRequests, Detail: "user %s sent %d requests, over %.1f%% (%t)",
+				Params: []Param{{Name: "userID", Type: "string"}, {Name: "count", Type: "int"},
+					{Name: "over", Type: "float64"}, {Name: "blocked", Type: "bool"}}},
+			expDetail: "user {userID} sent 1 requests, over 1.5% (true)",
+		},
+		"verbs should be used without params": {
+			entry:     Entry{Code: "U429", Behaviour: TooManyRequests, Detail: "user %s sent %d requests, %[2]d too many"},
+			expDetail: "user {arg1} sent 1 requests, 1 too many",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			c := New()
+			c.MustRegister(test.entry)
+			var ce lathos.ClientError
+			is.True(errors.As(c.New(test.entry.Code, test.entry.ExampleArgs()...), &ce))
+			is.Equal(ce.Detail(), test.expDetail)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		file       string
+		expEntries int
+		expErr     bool
+	}{
+		"yaml file should load": {
+			file: `
+package: usererrs
+entries:
+  - code: U404
+    name: UserNotFound
+    behaviour: not_found
+    detail: user %s not found
+    params:
+      - name: userID
+        type: string
+`,
+			expEntries: 1,
+		},
+		"json file should load": {
+			file:       `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "U409", "behaviour": "duplicate"}]}`,
+			expEntries: 2,
+		},
+		"empty file should load": {
+			file: "",
+		},
+		"duplicate codes should error": {
+			file:   `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "U404", "behaviour": "duplicate"}]}`,
+			expErr: true,
+		},
+		"invalid file should error": {
+			file:   `{"entries": `,
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			f, err := Load(strings.NewReader(test.file))
+			is.Equal(test.expErr, err != nil)
+			is.Equal(test.expEntries, len(f.Entries))
+		})
+	}
+}
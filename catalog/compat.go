@@ -0,0 +1,156 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes how an error code changed between two catalogs.
+type ChangeKind string
+
+// Kinds of change reported by Compare.
+const (
+	CodeAdded        ChangeKind = "added"
+	CodeRemoved      ChangeKind = "removed"
+	BehaviourChanged ChangeKind = "behaviour_changed"
+	StatusChanged    ChangeKind = "status_changed"
+	ParamsChanged    ChangeKind = "params_changed"
+	TitleChanged     ChangeKind = "title_changed"
+	DocsChanged      ChangeKind = "docs_changed"
+)
+
+// Breaking returns true if the change can break a client relying on the code.
+func (k ChangeKind) Breaking() bool {
+	switch k {
+	case CodeRemoved, BehaviourChanged, StatusChanged, ParamsChanged:
+		return true
+	}
+	return false
+}
+
+// Change is a single difference between two catalogs.
+type Change struct {
+	Code    string
+	Kind    ChangeKind
+	Message string
+}
+
+// String returns a human readable description of the change.
+func (c Change) String() string {
+	level := "info"
+	if c.Kind.Breaking() {
+		level = "BREAKING"
+	}
+	return fmt.Sprintf("%s %s: %s", level, c.Code, c.Message)
+}
+
+// Compare will return every change from the old catalog to the new, ordered by code.
+// Titles and statuses should be resolved, as they are when read from a Catalog or
+// a manifest, so a change to a default is detected.
+//
+// Removed codes, changed behaviours, changed HTTP statuses and changed detail params
+// are breaking, see ChangeKind.Breaking.
+func Compare(old, updated []Entry) []Change {
+	olds := make(map[string]Entry, len(old))
+	for _, e := range old {
+		olds[e.Code] = e
+	}
+	news := make(map[string]Entry, len(updated))
+	for _, e := range updated {
+		news[e.Code] = e
+	}
+	var cc []Change
+	for code, o := range olds {
+		n, ok := news[code]
+		if !ok {
+			cc = append(cc, Change{Code: code, Kind: CodeRemoved, Message: "code was removed"})
+			continue
+		}
+		cc = append(cc, compareEntry(o, n)...)
+	}
+	for code := range news {
+		if _, ok := olds[code]; !ok {
+			cc = append(cc, Change{Code: code, Kind: CodeAdded, Message: "code was added"})
+		}
+	}
+	sort.SliceStable(cc, func(i, j int) bool {
+		if cc[i].Code == cc[j].Code {
+			return cc[i].Kind < cc[j].Kind
+		}
+		return cc[i].Code < cc[j].Code
+	})
+	return cc
+}
+
+// Breaking returns only the breaking changes.
+func Breaking(cc []Change) []Change {
+	var bc []Change
+	for _, c := range cc {
+		if c.Kind.Breaking() {
+			bc = append(bc, c)
+		}
+	}
+	return bc
+}
+
+func compareEntry(o, n Entry) []Change {
+	var cc []Change
+	if o.Behaviour != n.Behaviour {
+		cc = append(cc, Change{Code: o.Code, Kind: BehaviourChanged,
+			Message: fmt.Sprintf("behaviour changed from %s to %s", o.Behaviour, n.Behaviour)})
+	}
+	if o.Status != n.Status {
+		cc = append(cc, Change{Code: o.Code, Kind: StatusChanged,
+			Message: fmt.Sprintf("status changed from %d to %d", o.Status, n.Status)})
+	}
+	if op, np := paramsOf(o), paramsOf(n); op != np {
+		cc = append(cc, Change{Code: o.Code, Kind: ParamsChanged,
+			Message: fmt.Sprintf("detail params changed from (%s) to (%s)", op, np)})
+	}
+	if o.Title != n.Title {
+		cc = append(cc, Change{Code: o.Code, Kind: TitleChanged,
+			Message: fmt.Sprintf("title changed from %q to %q", o.Title, n.Title)})
+	}
+	if o.Docs != n.Docs {
+		cc = append(cc, Change{Code: o.Code, Kind: DocsChanged, Message: "docs changed"})
+	}
+	return cc
+}
+
+// paramsOf describes the params of an entry in order, by name and type, if no
+// params are declared the format verbs in the detail are used instead. Any
+// difference, including a renamed or reordered param, is a change as a client
+// may match on them.
+func paramsOf(e Entry) string {
+	ss := make([]string, 0, len(e.Params))
+	for _, p := range e.Params {
+		ss = append(ss, p.Name+" "+p.Type)
+	}
+	if len(ss) > 0 {
+		return strings.Join(ss, ", ")
+	}
+	return strings.Join(verbs(e.Detail), ", ")
+}
+
+// verbs returns the formatting verbs in a format string, ie %s.
+func verbs(format string) []string {
+	var vv []string
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[j]) >= 0 {
+			j++
+		}
+		if j >= len(format) {
+			break
+		}
+		if format[j] != '%' {
+			vv = append(vv, format[i:j+1])
+		}
+		i = j
+	}
+	return vv
+}
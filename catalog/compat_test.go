@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	base := Entry{
+		Code: "U404", Behaviour: NotFound, Title: "User not found", Status: 404,
+		Detail: "user %s not found", Params: []Param{{Name: "userID", Type: "string"}},
+	}
+	tests := map[string]struct {
+		old      []Entry
+		updated  []Entry
+		expKinds []ChangeKind
+	}{
+		"identical catalogs should have no changes": {
+			old:     []Entry{base},
+			updated: []Entry{base},
+		},
+		"removed code should be breaking": {
+			old:      []Entry{base},
+			expKinds: []ChangeKind{CodeRemoved},
+		},
+		"added code should not be breaking": {
+			updated:  []Entry{base},
+			expKinds: []ChangeKind{CodeAdded},
+		},
+		"changed behaviour and status should be breaking": {
+			old: []Entry{base},
+			updated: []Entry{func() Entry {
+				e := base
+				e.Behaviour, e.Status = Conflict, 409
+				return e
+			}()},
+			expKinds: []ChangeKind{BehaviourChanged, StatusChanged},
+		},
+		"changed params should be breaking": {
+			old: []Entry{base},
+			updated: []Entry{func() Entry {
+				e := base
+				e.Params = []Param{{Name: "userID", Type: "int"}}
+				return e
+			}()},
+			expKinds: []ChangeKind{ParamsChanged},
+		},
+		"renamed param should be breaking": {
+			old: []Entry{base},
+			updated: []Entry{func() Entry {
+				e := base
+				e.Params = []Param{{Name: "id", Type: "string"}}
+				return e
+			}()},
+			expKinds: []ChangeKind{ParamsChanged},
+		},
+		"reordered params should be breaking": {
+			old: []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "item %s exists in %d",
+				Params: []Param{{Name: "item", Type: "string"}, {Name: "shelf", Type: "int"}}}},
+			updated: []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "shelf %d has item %s",
+				Params: []Param{{Name: "shelf", Type: "int"}, {Name: "item", Type: "string"}}}},
+			expKinds: []ChangeKind{ParamsChanged},
+		},
+		"added param should be breaking": {
+			old: []Entry{base},
+			updated: []Entry{func() Entry {
+				e := base
+				e.Params = append([]Param{{Name: "org", Type: "string"}}, base.Params...)
+				return e
+			}()},
+			expKinds: []ChangeKind{ParamsChanged},
+		},
+		"changed detail verbs without params should be breaking": {
+			old:      []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "item %s exists"}},
+			updated:  []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "item %d in %s exists"}},
+			expKinds: []ChangeKind{ParamsChanged},
+		},
+		"reworded detail should not be a change": {
+			old:     []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "item %s exists (100%%)"}},
+			updated: []Entry{{Code: "D001", Behaviour: Duplicate, Detail: "the item %s already exists"}},
+		},
+		"changed title and docs should not be breaking": {
+			old: []Entry{base},
+			updated: []Entry{func() Entry {
+				e := base
+				e.Title, e.Docs = "No such user", "Returned when a user is missing."
+				return e
+			}()},
+			expKinds: []ChangeKind{DocsChanged, TitleChanged},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			var kinds []ChangeKind
+			var breaking int
+			for _, c := range Compare(test.old, test.updated) {
+				kinds = append(kinds, c.Kind)
+				if c.Kind.Breaking() {
+					breaking++
+				}
+			}
+			is.Equal(test.expKinds, kinds)
+			is.Equal(breaking, len(Breaking(Compare(test.old, test.updated))))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Error is returned from Catalog.New for client behaviours. It wraps the errs
+// type for the behaviour, so all behaviour checks still work, and replaces
+// its title with the title registered for the code.
+type Error struct {
+	lathos.ClientError
+	entry *Entry
+	args  []interface{}
+}
+
+// Title returns the title registered for the error code.
+func (e Error) Title() string {
+	return e.entry.Title
+}
+
+// Error returns the title and detail of the error.
+func (e Error) Error() string {
+	return e.Title() + ": " + e.Detail()
+}
+
+// Unwrap returns the errs type the Error wraps.
+func (e Error) Unwrap() error {
+	return e.ClientError
+}
+
+// Status returns the HTTP status registered for the error code.
+func (e Error) Status() int {
+	return e.entry.Status
+}
+
+// Params returns the arguments the error was created with keyed by the
+// names of the entry's Params, this can be used to render translated details.
+func (e Error) Params() map[string]interface{} {
+	pp := make(map[string]interface{}, len(e.entry.Params))
+	for i, p := range e.entry.Params {
+		if i < len(e.args) {
+			pp[p.Name] = e.args[i]
+		}
+	}
+	return pp
+}
+
+// Entry returns the catalog entry the error was created from.
+func (e Error) Entry() Entry {
+	return *e.entry
+}
+
+// new creates an error from the entry, if the number of args doesn't match
+// the detail an internal error is returned rather than a malformed detail.
+func (e *Entry) new(args ...interface{}) error {
+	if n := argCount(e.Detail); len(args) != n {
+		return errs.NewErrInternal(pkgerrs.Errorf("error code %s expects %d args but got %d", e.Code, n, len(args)), e.Code)
+	}
+	detail := fmt.Sprintf(e.Detail, args...)
+	info := behaviourInfos[e.Behaviour]
+	if info.client != nil {
+		return Error{ClientError: info.client(e.Code, detail), entry: e, args: args}
+	}
+	kind := errs.KindInternal
+	if e.Behaviour == Retryable {
+		kind = errs.KindRetryable
+	}
+	return errs.New(kind, errs.WithCode(e.Code), errs.WithDetail(e.Title), errs.WithCause(cause(detail, args)))
+}
+
+// cause returns the cause of an internal error created from an entry, the
+// first error in args is kept so it can still be found with errors.Is.
+func cause(detail string, args []interface{}) error {
+	for _, a := range args {
+		if err, ok := a.(error); ok {
+			return pkgerrs.WithStack(causeErr{msg: detail, err: err})
+		}
+	}
+	return pkgerrs.New(detail)
+}
+
+// causeErr has the rendered detail as its message and wraps
+// the error passed as an argument.
+type causeErr struct {
+	msg string
+	err error
+}
+
+// Error returns the rendered detail.
+func (c causeErr) Error() string {
+	return c.msg
+}
+
+// Unwrap returns the error passed as an argument.
+func (c causeErr) Unwrap() error {
+	return c.err
+}
+
+// ExampleArgs returns sample args for the entry's detail so an example error
+// can be created to document it. Numbers and bools are given a sample value and
+// anything else a placeholder named after the param, ie {userID}, if no params
+// are declared the verbs in the detail decide the value.
+func (e Entry) ExampleArgs() []interface{} {
+	vv := argVerbs(e.Detail)
+	args := make([]interface{}, len(vv))
+	for i, v := range vv {
+		name, typ := "arg"+strconv.Itoa(i+1), ""
+		if i < len(e.Params) {
+			name, typ = e.Params[i].Name, e.Params[i].Type
+		}
+		args[i] = exampleArg(name, typ, v)
+	}
+	return args
+}
+
+// exampleArg returns a sample value for a param of typ, or if the type
+// isn't known a value for the verb it is formatted with.
+func exampleArg(name, typ string, verb byte) interface{} {
+	switch {
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), typ == "byte", typ == "rune":
+		return 1
+	case strings.HasPrefix(typ, "float"):
+		return 1.5
+	case typ == "bool":
+		return true
+	case typ == "error":
+		return pkgerrs.New("{" + name + "}")
+	case typ != "":
+		return "{" + name + "}"
+	}
+	switch verb {
+	case 'd', 'b', 'o', 'O', 'x', 'X', 'c', 'U':
+		return 1
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return 1.5
+	case 't':
+		return true
+	}
+	return "{" + name + "}"
+}
+
+// argCount returns the number of arguments used by a format string, explicit
+// indexes are followed so "%s or %[1]q" uses one.
+func argCount(format string) int {
+	return len(argVerbs(format))
+}
+
+// argVerbs returns the verb used to format each argument of a format string,
+// an argument used as a width or precision, ie %*d, has the verb d.
+func argVerbs(format string) []byte {
+	var vv []byte
+	var next int
+	use := func(verb byte) {
+		for len(vv) <= next {
+			vv = append(vv, 0)
+		}
+		if vv[next] == 0 {
+			vv[next] = verb
+		}
+		next++
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		j := i + 1
+		for ; j < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[j]) >= 0; j++ {
+			switch format[j] {
+			case '[':
+				end := strings.IndexByte(format[j:], ']')
+				if end < 0 {
+					return vv
+				}
+				if idx, err := strconv.Atoi(format[j+1 : j+end]); err == nil && idx > 0 {
+					next = idx - 1
+				}
+				j += end
+			case '*':
+				use('d')
+			}
+		}
+		if j >= len(format) {
+			break
+		}
+		if format[j] != '%' {
+			use(format[j])
+		}
+		i = j
+	}
+	return vv
+}
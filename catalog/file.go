@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"io"
+	"os"
+
+	pkgerrs "github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// File is a declarative error catalog, it can be written in YAML or JSON:
+//
+//	package: usererrs
+//	entries:
+//	  - code: U404
+//	    name: UserNotFound
+//	    behaviour: not_found
+//	    title: User not found
+//	    detail: user %s not found
+//	    params:
+//	      - name: userID
+//	        type: string
+//	    docs: Returned when a user does not exist or has been deleted.
+type File struct {
+	// Package is the name of the Go package generated from the file.
+	Package string  `json:"package,omitempty" yaml:"package,omitempty"`
+	Entries []Entry `json:"entries" yaml:"entries"`
+}
+
+// Load will read a File in YAML or JSON format from r.
+// Every entry is validated and codes must be unique.
+func Load(r io.Reader) (File, error) {
+	var f File
+	if err := yaml.NewDecoder(r).Decode(&f); err != nil && !pkgerrs.Is(err, io.EOF) {
+		return File{}, pkgerrs.Wrap(err, "failed to decode catalog file")
+	}
+	if err := New().Register(f.Entries...); err != nil {
+		return File{}, err
+	}
+	return f, nil
+}
+
+// LoadFile will open and read the catalog File at path, see Load.
+func LoadFile(path string) (File, error) {
+	r, err := os.Open(path) //nolint:gosec // path is supplied by the developer.
+	if err != nil {
+		return File{}, pkgerrs.Wrapf(err, "failed to open catalog file %s", path)
+	}
+	defer r.Close()
+	f, err := Load(r)
+	return f, pkgerrs.Wrapf(err, "invalid catalog file %s", path)
+}
+
+// Catalog will return a new Catalog containing the entries of the file.
+func (f File) Catalog() (*Catalog, error) {
+	c := New()
+	if err := c.Register(f.Entries...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Manifest will return a File containing every entry of the catalog, ordered by code,
+// with default titles and statuses filled in. It is intended to be published to clients
+// and can be read back with Load.
+func (c *Catalog) Manifest() File {
+	return File{Entries: c.Entries()}
+}
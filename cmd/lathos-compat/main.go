@@ -0,0 +1,81 @@
+// Command lathos-compat compares two versions of an error catalog, or two manifests
+// generated by lathos-gen, and reports the changes between them.
+//
+//	lathos-compat old/errors.yaml errors.yaml
+//
+// It exits with a non-zero status if there are breaking changes, such as a removed code
+// or a changed behaviour, HTTP status or detail params, so it can be used to gate merges.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// exit codes returned by the command.
+const (
+	exitOK       = 0
+	exitBreaking = 1
+	exitError    = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lathos-compat", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	quiet := fs.Bool("q", false, "only report breaking changes")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: lathos-compat [-q] <old catalog> <new catalog>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitError
+	}
+	old, err := load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "lathos-compat:", err)
+		return exitError
+	}
+	updated, err := load(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, "lathos-compat:", err)
+		return exitError
+	}
+	changes := catalog.Compare(old, updated)
+	breaking := catalog.Breaking(changes)
+	if *quiet {
+		changes = breaking
+	}
+	for _, c := range changes {
+		fmt.Fprintln(stdout, c)
+	}
+	if len(breaking) > 0 {
+		fmt.Fprintf(stderr, "lathos-compat: %d breaking change(s) found\n", len(breaking))
+		return exitBreaking
+	}
+	return exitOK
+}
+
+// load reads a catalog file and returns its entries with defaults resolved.
+func load(path string) ([]catalog.Entry, error) {
+	f, err := catalog.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := f.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	return c.Entries(), nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	const base = `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "D001", "behaviour": "duplicate"}]}`
+	tests := map[string]struct {
+		old     string
+		updated string
+		args    []string
+		expCode int
+		expOut  string
+	}{
+		"no changes should exit ok": {
+			old:     base,
+			updated: base,
+			expCode: exitOK,
+		},
+		"added code should exit ok": {
+			old:     base,
+			updated: `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "D001", "behaviour": "duplicate"}, {"code": "C001", "behaviour": "conflict"}]}`,
+			expCode: exitOK,
+			expOut:  "info C001: code was added",
+		},
+		"added code should not be printed when quiet": {
+			old:     base,
+			updated: `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "D001", "behaviour": "duplicate"}, {"code": "C001", "behaviour": "conflict"}]}`,
+			args:    []string{"-q"},
+			expCode: exitOK,
+		},
+		"removed code should exit breaking": {
+			old:     base,
+			updated: `{"entries": [{"code": "U404", "behaviour": "not_found"}]}`,
+			expCode: exitBreaking,
+			expOut:  "BREAKING D001: code was removed",
+		},
+		"changed status should exit breaking": {
+			old:     base,
+			updated: `{"entries": [{"code": "U404", "behaviour": "not_found"}, {"code": "D001", "behaviour": "duplicate", "status": 422}]}`,
+			expCode: exitBreaking,
+			expOut:  "BREAKING D001: status changed from 409 to 422",
+		},
+		"renamed param should exit breaking": {
+			old:     `{"entries": [{"code": "U404", "behaviour": "not_found", "detail": "user %s not found", "params": [{"name": "userID", "type": "string"}]}]}`,
+			updated: `{"entries": [{"code": "U404", "behaviour": "not_found", "detail": "user %s not found", "params": [{"name": "id", "type": "string"}]}]}`,
+			expCode: exitBreaking,
+			expOut:  "BREAKING U404: detail params changed from (userID string) to (id string)",
+		},
+		"invalid file should exit error": {
+			old:     base,
+			updated: `{"entries": `,
+			expCode: exitError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			dir := t.TempDir()
+			old, updated := filepath.Join(dir, "old.json"), filepath.Join(dir, "new.json")
+			is.NoErr(os.WriteFile(old, []byte(test.old), 0o600))
+			is.NoErr(os.WriteFile(updated, []byte(test.updated), 0o600))
+
+			var stdout, stderr bytes.Buffer
+			code := run(append(test.args, old, updated), &stdout, &stderr)
+			is.Equal(test.expCode, code)
+			is.Equal(test.expOut, strings.TrimSpace(stdout.String()))
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"strings"
+	"text/template"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// paramTypes are the types params can be declared as, they are limited
+// to builtin types so the generated code doesn't need extra imports.
+var paramTypes = map[string]string{ //nolint:gochecknoglobals // read only lookup table.
+	"string": `"test"`, "bool": "true", "error": `errors.New("test")`,
+	"int": "1", "int8": "1", "int16": "1", "int32": "1", "int64": "1",
+	"uint": "1", "uint8": "1", "uint16": "1", "uint32": "1", "uint64": "1",
+	"float32": "1", "float64": "1", "byte": "1", "rune": "1",
+}
+
+// checks maps a behaviour to its catalog constant and the
+// lathos function that checks for it.
+var checks = map[catalog.Behaviour]struct{ constant, check string }{ //nolint:gochecknoglobals // read only lookup table.
+	catalog.NotFound:         {"NotFound", "IsNotFound"},
+	catalog.Duplicate:        {"Duplicate", "IsDuplicate"},
+	catalog.Conflict:         {"Conflict", "IsConflict"},
+	catalog.BadRequest:       {"BadRequest", "IsBadRequest"},
+	catalog.NotAuthenticated: {"NotAuthenticated", "IsNotAuthenticated"},
+	catalog.NotAuthorised:    {"NotAuthorised", "IsNotAuthorised"},
+	catalog.CannotProcess:    {"CannotProcess", "IsCannotProcess"},
+	catalog.TooManyRequests:  {"TooManyRequests", "IsTooManyRequests"},
+	catalog.Unavailable:      {"Unavailable", "IsUnavailable"},
+	catalog.Timeout:          {"Timeout", "IsTimeout"},
+	catalog.Canceled:         {"Canceled", "IsCanceled"},
+	catalog.Internal:         {"Internal", "IsInternalError"},
+	catalog.Retryable:        {"Retryable", "IsRetryable"},
+}
+
+// generateGo reads the catalog at in and writes the constructors
+// to out and their tests to a matching _test.go file.
+func generateGo(in, out, pkg string) error {
+	f, err := catalog.LoadFile(in)
+	if err != nil {
+		return err
+	}
+	if pkg != "" {
+		f.Package = pkg
+	}
+	if f.Package == "" {
+		return pkgerrs.New("package name must be set in the catalog file or with -package")
+	}
+	for _, e := range f.Entries {
+		if err := validateEntry(e); err != nil {
+			return err
+		}
+	}
+	if err := writeTemplate(out, goTemplate, f); err != nil {
+		return err
+	}
+	return writeTemplate(strings.TrimSuffix(out, ".go")+"_test.go", goTestTemplate, f)
+}
+
+// validateEntry checks an entry can be generated.
+func validateEntry(e catalog.Entry) error {
+	if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+		return fmt.Errorf("error code %s: name %q must be an exported Go identifier", e.Code, e.Name)
+	}
+	for _, p := range e.Params {
+		if !token.IsIdentifier(p.Name) || token.IsKeyword(p.Name) {
+			return fmt.Errorf("error code %s: param name %q must be a Go identifier", e.Code, p.Name)
+		}
+		if _, ok := paramTypes[p.Type]; !ok {
+			return fmt.Errorf("error code %s: param %s has unsupported type %q", e.Code, p.Name, p.Type)
+		}
+	}
+	if n := countVerbs(e.Detail); n != len(e.Params) {
+		return fmt.Errorf("error code %s: detail has %d format verbs but %d params", e.Code, n, len(e.Params))
+	}
+	return nil
+}
+
+// countVerbs returns the number of formatting verbs in a format string.
+func countVerbs(format string) int {
+	var n int
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+func writeTemplate(path string, tmpl *template.Template, data interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return pkgerrs.Wrapf(err, "failed to generate %s", path)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return pkgerrs.Wrapf(err, "failed to format %s", path)
+	}
+	return pkgerrs.Wrapf(os.WriteFile(path, src, 0o644), "failed to write %s", path) //nolint:gosec // generated source is not sensitive.
+}
+
+var funcs = template.FuncMap{ //nolint:gochecknoglobals // template helpers.
+	"comment": func(s string) string {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return ""
+		}
+		return "// " + strings.ReplaceAll(s, "\n", "\n// ")
+	},
+	"args": func(pp []catalog.Param) string {
+		ss := make([]string, 0, len(pp))
+		for _, p := range pp {
+			ss = append(ss, p.Name+" "+p.Type)
+		}
+		return strings.Join(ss, ", ")
+	},
+	"names": func(pp []catalog.Param) string {
+		ss := make([]string, 0, len(pp))
+		for _, p := range pp {
+			ss = append(ss, ", "+p.Name)
+		}
+		return strings.Join(ss, "")
+	},
+	"samples": func(pp []catalog.Param) string {
+		ss := make([]string, 0, len(pp))
+		for _, p := range pp {
+			ss = append(ss, paramTypes[p.Type])
+		}
+		return strings.Join(ss, ", ")
+	},
+	"usesError": func(ee []catalog.Entry) bool {
+		for _, e := range ee {
+			for _, p := range e.Params {
+				if p.Type == "error" {
+					return true
+				}
+			}
+		}
+		return false
+	},
+	"check":     func(b catalog.Behaviour) string { return checks[b].check },
+	"behaviour": func(b catalog.Behaviour) string { return checks[b].constant },
+}
+
+var goTemplate = template.Must(template.New("go").Funcs(funcs).Parse(`// Code generated by lathos-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// Error codes defined in the catalog.
+const (
+{{- range .Entries }}
+	Code{{ .Name }} = {{ printf "%q" .Code }}
+{{- end }}
+)
+
+// Catalog contains every error code defined in this package.
+var Catalog = catalog.New()
+
+func init() {
+	Catalog.MustRegister(
+{{- range .Entries }}
+		catalog.Entry{
+			Code:      Code{{ .Name }},
+			Behaviour: catalog.{{ behaviour .Behaviour }},
+			Title:     {{ printf "%q" .Title }},
+			Detail:    {{ printf "%q" .Detail }},
+			Status:    {{ .Status }},
+			Docs:      {{ printf "%q" .Docs }},
+			Name:      {{ printf "%q" .Name }},
+			{{- with .Causes }}
+			Causes: []string{
+				{{- range . }}
+				{{ printf "%q" . }},
+				{{- end }}
+			},
+			{{- end }}
+			{{- with .Remediation }}
+			Remediation: {{ printf "%q" . }},
+			{{- end }}
+			{{- with .Params }}
+			Params: []catalog.Param{
+				{{- range . }}
+				{Name: {{ printf "%q" .Name }}, Type: {{ printf "%q" .Type }}},
+				{{- end }}
+			},
+			{{- end }}
+		},
+{{- end }}
+	)
+}
+{{ range .Entries }}
+// Err{{ .Name }} returns a new {{ .Code }} error.
+{{- with comment .Docs }}
+//
+{{ . }}
+{{- end }}
+func Err{{ .Name }}({{ args .Params }}) error {
+	return Catalog.New(Code{{ .Name }}{{ names .Params }})
+}
+{{ end -}}
+`))
+
+var goTestTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Code generated by lathos-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if usesError .Entries }}
+	"errors"
+{{- end }}
+	"testing"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/catalog"
+)
+{{ range .Entries }}
+func TestErr{{ .Name }}(t *testing.T) {
+	t.Parallel()
+	err := Err{{ .Name }}({{ samples .Params }})
+	if !lathos.{{ check .Behaviour }}(err) {
+		t.Fatalf("expected {{ .Behaviour }} error, got %v", err)
+	}
+	e, ok := Catalog.Lookup(Code{{ .Name }})
+	if !ok {
+		t.Fatal("expected {{ .Code }} to be registered")
+	}
+	if e.Behaviour != catalog.{{ behaviour .Behaviour }} {
+		t.Fatalf("expected behaviour {{ .Behaviour }}, got %s", e.Behaviour)
+	}
+}
+{{ end -}}
+`))
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+func TestValidateEntry(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		entry  catalog.Entry
+		expErr string
+	}{
+		"valid entry should pass": {
+			entry: catalog.Entry{
+				Code: "U404", Name: "UserNotFound", Detail: "user %s not found",
+				Params: []catalog.Param{{Name: "userID", Type: "string"}},
+			},
+		},
+		"escaped percent should not count as a verb": {
+			entry: catalog.Entry{Code: "Q001", Name: "Quota", Detail: "quota is 100%%"},
+		},
+		"unexported name should fail": {
+			entry:  catalog.Entry{Code: "U404", Name: "userNotFound"},
+			expErr: `name "userNotFound" must be an exported Go identifier`,
+		},
+		"keyword param should fail": {
+			entry: catalog.Entry{
+				Code: "U404", Name: "UserNotFound", Detail: "user %s not found",
+				Params: []catalog.Param{{Name: "type", Type: "string"}},
+			},
+			expErr: `param name "type" must be a Go identifier`,
+		},
+		"unsupported param type should fail": {
+			entry: catalog.Entry{
+				Code: "U404", Name: "UserNotFound", Detail: "user %s not found",
+				Params: []catalog.Param{{Name: "id", Type: "uuid.UUID"}},
+			},
+			expErr: `param id has unsupported type "uuid.UUID"`,
+		},
+		"mismatched params should fail": {
+			entry: catalog.Entry{
+				Code: "U404", Name: "UserNotFound", Detail: "user %s in %s not found",
+				Params: []catalog.Param{{Name: "id", Type: "string"}},
+			},
+			expErr: "detail has 2 format verbs but 1 params",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := validateEntry(test.entry)
+			if test.expErr == "" {
+				is.NoErr(err)
+				return
+			}
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), test.expErr))
+		})
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	dir := t.TempDir()
+	in := filepath.Join(dir, "errors.json")
+	is.NoErr(os.WriteFile(in, []byte(`{
+		"entries": [
+			{"code": "U404", "name": "UserNotFound", "behaviour": "not_found", "detail": "user %s not found",
+			 "params": [{"name": "userID", "type": "string"}], "docs": "User is missing."},
+			{"code": "R001", "name": "TryAgain", "behaviour": "retryable"}
+		]
+	}`), 0o600))
+
+	out := filepath.Join(dir, "errors_gen.go")
+	is.NoErr(run([]string{"-in", in, "-package", "usererrs"}))
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, out, nil, parser.ParseComments)
+	is.NoErr(err)
+	is.Equal(f.Name.Name, "usererrs")
+	is.True(f.Scope.Lookup("ErrUserNotFound") != nil)
+	is.True(f.Scope.Lookup("ErrTryAgain") != nil)
+	is.True(f.Scope.Lookup("CodeUserNotFound") != nil)
+
+	tf, err := parser.ParseFile(fset, filepath.Join(dir, "errors_gen_test.go"), nil, 0)
+	is.NoErr(err)
+	is.True(tf.Scope.Lookup("TestErrUserNotFound") != nil)
+}
+
+func TestGenerateGo_NoPackage(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	in := filepath.Join(t.TempDir(), "errors.yaml")
+	is.NoErr(os.WriteFile(in, []byte("entries: []\n"), 0o600))
+	err := generateGo(in, in+".go", "")
+	is.True(err != nil)
+}
@@ -0,0 +1,100 @@
+// Command lathos-gen generates typed error constructors from a declarative
+// error catalog written in YAML or JSON, see catalog.File for the format.
+//
+// It is intended to be run with go generate:
+//
+//	//go:generate go run github.com/theflyingcodr/lathos/cmd/lathos-gen -in errors.yaml
+//
+// For each entry a code constant and a constructor, named from the entry's name
+// and taking its params, are generated along with a test for each constructor:
+//
+//	const CodeUserNotFound = "U404"
+//
+//	func ErrUserNotFound(userID string) error
+//
+// The generated errors are created from a generated catalog so have the registered
+// title, behaviour and status.
+//
+// The catalog can also be published to frontend and mobile clients, -ts writes a
+// TypeScript module with a constant for each code and -manifest writes a JSON
+// manifest of every code with its title, HTTP status and behaviour:
+//
+//	lathos-gen -in errors.yaml -go=false -ts errors.ts -manifest errors.json
+//
+// OpenAPI 3 components, with a Problem schema and a response for each HTTP status
+// listing its codes, can be written with -openapi, the file extension selects
+// JSON or YAML:
+//
+//	lathos-gen -in errors.yaml -go=false -openapi errors.openapi.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "lathos-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("lathos-gen", flag.ContinueOnError)
+	in := fs.String("in", "errors.yaml", "catalog file to read, in YAML or JSON format")
+	out := fs.String("out", "", "go file to write, defaults to the input file name with a _gen.go suffix")
+	pkg := fs.String("package", os.Getenv("GOPACKAGE"), "package name, overrides the package in the catalog file")
+	goOut := fs.Bool("go", true, "generate go constructors")
+	ts := fs.String("ts", "", "if set, a TypeScript module of the error codes is written to this file")
+	manifest := fs.String("manifest", "", "if set, a JSON manifest of the error codes is written to this file")
+	openapi := fs.String("openapi", "", "if set, OpenAPI components for the error responses are written to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(*in, ext(*in)) + "_gen.go"
+	}
+	if *goOut {
+		if err := generateGo(*in, *out, *pkg); err != nil {
+			return err
+		}
+	}
+	if *ts == "" && *manifest == "" && *openapi == "" {
+		return nil
+	}
+	f, err := catalog.LoadFile(*in)
+	if err != nil {
+		return err
+	}
+	cat, err := f.Catalog()
+	if err != nil {
+		return err
+	}
+	if *ts != "" {
+		if err := generateTS(cat, *ts); err != nil {
+			return err
+		}
+	}
+	if *manifest != "" {
+		if err := generateManifest(cat, *manifest); err != nil {
+			return err
+		}
+	}
+	if *openapi != "" {
+		return generateOpenAPI(cat, *openapi)
+	}
+	return nil
+}
+
+// ext returns the file extension of path including the dot.
+func ext(path string) string {
+	if i := strings.LastIndexByte(path, '.'); i > strings.LastIndexByte(path, '/') {
+		return path[i:]
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	pkgerrs "github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/problem"
+)
+
+// generateOpenAPI writes OpenAPI components for the catalog to out,
+// as YAML if out has a .yaml or .yml extension and JSON otherwise.
+func generateOpenAPI(cat *catalog.Catalog, out string) error {
+	components := problem.Components(cat.Entries())
+	var buf bytes.Buffer
+	var err error
+	switch ext(out) {
+	case ".yaml", ".yml":
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(components)
+	default:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(components)
+	}
+	if err != nil {
+		return pkgerrs.Wrap(err, "failed to encode openapi components")
+	}
+	return pkgerrs.Wrapf(os.WriteFile(out, buf.Bytes(), 0o644), "failed to write %s", out) //nolint:gosec // generated source is not sensitive.
+}
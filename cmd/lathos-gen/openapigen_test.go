@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateOpenAPI(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		out       string
+		unmarshal func([]byte, interface{}) error
+	}{
+		"yaml extension should write yaml": {
+			out:       "errors.openapi.yaml",
+			unmarshal: yaml.Unmarshal,
+		},
+		"json extension should write json": {
+			out:       "errors.openapi.json",
+			unmarshal: json.Unmarshal,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			dir := t.TempDir()
+			in := filepath.Join(dir, "errors.yaml")
+			is.NoErr(os.WriteFile(in, []byte("entries:\n  - code: U404\n    behaviour: not_found\n"), 0o600))
+			out := filepath.Join(dir, test.out)
+			is.NoErr(run([]string{"-in", in, "-go=false", "-openapi", out}))
+
+			bb, err := os.ReadFile(out)
+			is.NoErr(err)
+			var spec struct {
+				Components struct {
+					Schemas   map[string]interface{} `json:"schemas" yaml:"schemas"`
+					Responses map[string]interface{} `json:"responses" yaml:"responses"`
+				} `json:"components" yaml:"components"`
+			}
+			is.NoErr(test.unmarshal(bb, &spec))
+			is.True(spec.Components.Schemas["Problem"] != nil)
+			is.True(spec.Components.Responses["NotFound"] != nil)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"text/template"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// generateManifest writes the resolved catalog entries as JSON to out.
+func generateManifest(cat *catalog.Catalog, out string) error {
+	bb, err := json.MarshalIndent(cat.Manifest(), "", "  ")
+	if err != nil {
+		return pkgerrs.Wrap(err, "failed to encode manifest")
+	}
+	return pkgerrs.Wrapf(os.WriteFile(out, append(bb, '\n'), 0o644), "failed to write %s", out) //nolint:gosec // generated source is not sensitive.
+}
+
+// generateTS writes a TypeScript module listing every error code to out.
+func generateTS(cat *catalog.Catalog, out string) error {
+	var buf bytes.Buffer
+	if err := tsTemplate.Execute(&buf, struct {
+		Behaviours []catalog.Behaviour
+		Entries    []catalog.Entry
+	}{
+		Behaviours: catalog.Behaviours(),
+		Entries:    cat.Entries(),
+	}); err != nil {
+		return pkgerrs.Wrapf(err, "failed to generate %s", out)
+	}
+	return pkgerrs.Wrapf(os.WriteFile(out, buf.Bytes(), 0o644), "failed to write %s", out) //nolint:gosec // generated source is not sensitive.
+}
+
+// jsString quotes s as a JSON string which is also a valid TypeScript string.
+func jsString(s interface{}) string {
+	bb, _ := json.Marshal(s)
+	return string(bb)
+}
+
+var tsTemplate = template.Must(template.New("ts").Funcs(template.FuncMap{
+	"js": jsString,
+	"key": func(e catalog.Entry) string {
+		if e.Name != "" {
+			return e.Name
+		}
+		return jsString(e.Code)
+	},
+}).Parse(`// Code generated by lathos-gen. DO NOT EDIT.
+
+/** The lathos behaviour an error is raised with. */
+export type ErrorBehaviour =
+{{- range $i, $b := .Behaviours }}
+  | {{ js $b }}
+{{- end }};
+
+/** Every error code defined in the catalog. */
+export const ErrorCodes = {
+{{- range .Entries }}
+  {{ key . }}: {{ js .Code }},
+{{- end }}
+} as const;
+
+export type ErrorCode = (typeof ErrorCodes)[keyof typeof ErrorCodes];
+
+export interface ErrorDefinition {
+  code: ErrorCode;
+  title: string;
+  status: number;
+  behaviour: ErrorBehaviour;
+}
+
+/** The definition of each error code, keyed by code. */
+export const ErrorDefinitions: Record<ErrorCode, ErrorDefinition> = {
+{{- range .Entries }}
+  {{ js .Code }}: { code: {{ js .Code }}, title: {{ js .Title }}, status: {{ .Status }}, behaviour: {{ js .Behaviour }} },
+{{- end }}
+};
+
+/** Returns true if code is a known error code. */
+export function isErrorCode(code: string): code is ErrorCode {
+  return Object.prototype.hasOwnProperty.call(ErrorDefinitions, code);
+}
+`))
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+func TestGenerateTSAndManifest(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	dir := t.TempDir()
+	in := filepath.Join(dir, "errors.yaml")
+	is.NoErr(os.WriteFile(in, []byte(`
+entries:
+  - code: U404
+    name: UserNotFound
+    behaviour: not_found
+    title: User not found
+  - code: "D001"
+    behaviour: duplicate
+`), 0o600))
+	ts := filepath.Join(dir, "errors.ts")
+	manifest := filepath.Join(dir, "errors.json")
+
+	is.NoErr(run([]string{"-in", in, "-go=false", "-ts", ts, "-manifest", manifest}))
+
+	_, err := os.Stat(filepath.Join(dir, "errors_gen.go"))
+	is.True(os.IsNotExist(err)) // go generation was disabled
+
+	bb, err := os.ReadFile(ts)
+	is.NoErr(err)
+	src := string(bb)
+	is.True(strings.Contains(src, `  UserNotFound: "U404",`))
+	is.True(strings.Contains(src, `  "D001": "D001",`))
+	is.True(strings.Contains(src,
+		`"U404": { code: "U404", title: "User not found", status: 404, behaviour: "not_found" },`))
+	is.True(strings.Contains(src,
+		`"D001": { code: "D001", title: "Item already exists", status: 409, behaviour: "duplicate" },`))
+
+	f, err := catalog.LoadFile(manifest)
+	is.NoErr(err)
+	is.Equal(len(f.Entries), 2)
+	is.Equal(f.Entries[0].Code, "D001")
+	is.Equal(f.Entries[0].Status, 409)
+	is.Equal(f.Entries[1].Title, "User not found")
+}
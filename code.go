@@ -0,0 +1,22 @@
+package lathos
+
+// CodeMatcher can be used as the target of errors.Is to check if an error
+// in the chain has a specific code, rather than only a behaviour:
+//
+//	if errors.Is(err, lathos.CodeMatcher("D001")) {
+//		// handle the duplicate
+//	}
+//
+// Errors must implement Is(target error) bool to be matched, the errs
+// package types all support this for their Code().
+type CodeMatcher string
+
+// Error implements the error interface.
+func (c CodeMatcher) Error() string {
+	return "error code " + string(c)
+}
+
+// Matches returns true if code is not empty and equal to the matcher.
+func (c CodeMatcher) Matches(code string) bool {
+	return c != "" && string(c) == code
+}
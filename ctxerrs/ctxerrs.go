@@ -0,0 +1,74 @@
+// Package ctxerrs translates context cancellation and deadline errors into
+// lathos Canceled and Timeout errors, so a client going away isn't logged
+// as an internal error:
+//
+//	if err := svc.Do(r.Context()); err != nil {
+//		return ctxerrs.FromContext(r.Context(), err)
+//	}
+package ctxerrs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Codes of the errors returned by Translate and FromContext.
+const (
+	CodeDeadlineExceeded = "CTX_DEADLINE_EXCEEDED"
+	CodeCanceled         = "CTX_CANCELED"
+)
+
+// Translate maps an error with context.DeadlineExceeded anywhere in its chain
+// to a Timeout error and one with context.Canceled to a Canceled error, the
+// original error is kept as the cause.
+//
+// Any other error, including nil, is returned unchanged.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return deadlineExceeded(err)
+	case errors.Is(err, context.Canceled):
+		return canceled(err)
+	}
+	return err
+}
+
+// FromContext is the same as Translate but if ctx is done, an unclassified err is
+// translated using the reason ctx ended. Both err and the context.Cause of ctx are
+// kept as the cause, so a cause given to a context.CancelCauseFunc can be found with
+// errors.Is. If ctx is not done err is passed to Translate.
+//
+// Errors that are already client or internal errors are returned unchanged, even if
+// ctx is done, so a fault that happened while the request was ending isn't hidden.
+func FromContext(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return Translate(err)
+	}
+	if lathos.IsClientError(err) || lathos.IsInternalError(err) {
+		return err
+	}
+	cause := err
+	if c := context.Cause(ctx); !errors.Is(err, c) {
+		cause = errors.Join(err, c)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return deadlineExceeded(cause)
+	}
+	return canceled(cause)
+}
+
+func deadlineExceeded(cause error) error {
+	return errs.New(errs.KindTimeout, errs.WithCode(CodeDeadlineExceeded),
+		errs.WithDetail("the request did not complete in time"), errs.WithCause(cause))
+}
+
+func canceled(cause error) error {
+	return errs.New(errs.KindCanceled, errs.WithCode(CodeCanceled),
+		errs.WithDetail("the request was cancelled"), errs.WithCause(cause))
+}
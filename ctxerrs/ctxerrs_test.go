@@ -0,0 +1,140 @@
+package ctxerrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err   error
+		code  string
+		check func(err error) bool
+	}{
+		"deadline exceeded should be a timeout": {
+			err:   fmt.Errorf("query users: %w", context.DeadlineExceeded),
+			code:  CodeDeadlineExceeded,
+			check: lathos.IsTimeout,
+		},
+		"canceled should be canceled": {
+			err:   pkgerrs.Wrap(context.Canceled, "call payments"),
+			code:  CodeCanceled,
+			check: lathos.IsCanceled,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := Translate(test.err)
+			is.True(test.check(err))
+			is.True(!lathos.IsInternalError(err))
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			is.True(errors.Is(err, test.err))
+			is.Equal(lathos.SeverityOf(err) < lathos.LevelError, true) // doesn't page anyone
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+	errGone := errors.New("client went away")
+	tests := map[string]struct {
+		ctx   func() context.Context
+		err   error
+		code  string
+		check func(err error) bool
+		cause error
+	}{
+		"cancel cause should be kept": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(errGone)
+				return ctx
+			},
+			err:   errors.New("read body: unexpected EOF"),
+			code:  CodeCanceled,
+			check: lathos.IsCanceled,
+			cause: errGone,
+		},
+		"expired deadline should be a timeout": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+				t.Cleanup(cancel)
+				<-ctx.Done()
+				return ctx
+			},
+			err:   errors.New("io: read/write on closed pipe"),
+			code:  CodeDeadlineExceeded,
+			check: lathos.IsTimeout,
+			cause: context.DeadlineExceeded,
+		},
+		"live context should translate the error": {
+			ctx:   context.Background,
+			err:   fmt.Errorf("wrap: %w", context.Canceled),
+			code:  CodeCanceled,
+			check: lathos.IsCanceled,
+			cause: context.Canceled,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := FromContext(test.ctx(), test.err)
+			is.True(test.check(err))
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			is.True(errors.Is(err, test.cause))
+		})
+	}
+}
+
+func TestTranslate_PassThrough(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(Translate(nil))
+	is.NoErr(FromContext(context.Background(), nil))
+	other := errors.New("boom")
+	is.Equal(Translate(other), other)
+	is.Equal(FromContext(context.Background(), other), other)
+}
+
+func TestFromContext_Classified(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := map[string]struct {
+		err   error
+		check func(err error) bool
+	}{
+		"not found error should be unchanged": {
+			err:   errs.NewErrNotFound("U404", "user not found"),
+			check: lathos.IsNotFound,
+		},
+		"validation error should be unchanged": {
+			err:   errs.NewErrValidation("V001", "", lathos.Violation{Field: "/name", Rule: "required"}),
+			check: lathos.IsBadRequest,
+		},
+		"internal error should be unchanged": {
+			err:   pkgerrs.Wrap(errs.NewErrInternal(errors.New("disk full"), "I001"), "save user"),
+			check: lathos.IsInternalError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := FromContext(ctx, test.err)
+			is.Equal(err, test.err)
+			is.True(test.check(err))
+			is.True(!lathos.IsCanceled(err))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package lathos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxReasonLength is the longest reason allowed by ParseErrorInfo.
+const maxReasonLength = 63
+
+// ErrorInfo is a structured error code, following the style of Google AIP-193.
+// The Domain is the service or system that owns the error, such as users.example.com,
+// and the Reason is an UPPER_SNAKE identifier unique within that domain.
+// Together they identify an error across many services where a bare code
+// such as E404 would be ambiguous.
+type ErrorInfo struct {
+	Domain   string
+	Reason   string
+	Metadata map[string]string
+}
+
+// String returns the info formatted as domain/REASON, or the reason alone
+// if there is no domain. This is the format read by ParseErrorInfo.
+func (e ErrorInfo) String() string {
+	if e.Domain == "" {
+		return e.Reason
+	}
+	return e.Domain + "/" + e.Reason
+}
+
+// ParseErrorInfo will parse a code in the format domain/REASON, the domain is optional.
+// An error is returned if the reason is not an UPPER_SNAKE identifier.
+func ParseErrorInfo(code string) (ErrorInfo, error) {
+	var info ErrorInfo
+	info.Reason = code
+	if i := strings.LastIndex(code, "/"); i >= 0 {
+		info.Domain, info.Reason = code[:i], code[i+1:]
+		if info.Domain == "" {
+			return ErrorInfo{}, errors.Errorf("code %q has an empty domain", code)
+		}
+	}
+	if !validReason(info.Reason) {
+		return ErrorInfo{}, errors.Errorf("code %q reason must be UPPER_SNAKE_CASE and at most %d characters", code, maxReasonLength)
+	}
+	return info, nil
+}
+
+// validReason returns true if r matches [A-Z][A-Z0-9_]*[A-Z0-9].
+func validReason(r string) bool {
+	if r == "" || len(r) > maxReasonLength {
+		return false
+	}
+	for i, c := range r {
+		switch {
+		case c >= 'A' && c <= 'Z':
+		case (c >= '0' && c <= '9') && i > 0:
+		case c == '_' && i > 0 && i < len(r)-1:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Namespaced can be implemented by errors that have a structured code,
+// it is an optional behaviour on top of ClientError.Code() and InternalError.Code().
+type Namespaced interface {
+	ErrorInfo() ErrorInfo
+}
+
+// IsNamespaced will check that an error implements the Namespaced interface.
+func IsNamespaced(err error) bool {
+	var t Namespaced
+	return errors.As(err, &t)
+}
+
+// ErrorInfoOf returns the ErrorInfo of the first Namespaced error in the chain.
+// If there isn't one, the Code() of the first ClientError or InternalError
+// is parsed with ParseErrorInfo. False is returned if neither is found or the
+// code can't be parsed.
+func ErrorInfoOf(err error) (ErrorInfo, bool) {
+	var n Namespaced
+	if errors.As(err, &n) {
+		return n.ErrorInfo(), true
+	}
+	code := codeOf(err)
+	if code == "" {
+		return ErrorInfo{}, false
+	}
+	info, perr := ParseErrorInfo(code)
+	if perr != nil {
+		return ErrorInfo{}, false
+	}
+	return info, true
+}
+
+// WithErrorInfo will wrap err so that it is Namespaced with the provided info,
+// all other behaviours are still available through the wrapped error.
+func WithErrorInfo(err error, info ErrorInfo) error {
+	if err == nil {
+		return nil
+	}
+	return namespacedErr{err: err, info: info}
+}
+
+type namespacedErr struct {
+	err  error
+	info ErrorInfo
+}
+
+// Error implements the error interface.
+func (n namespacedErr) Error() string {
+	return n.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (n namespacedErr) Unwrap() error {
+	return n.err
+}
+
+// ErrorInfo implements the Namespaced interface.
+func (n namespacedErr) ErrorInfo() ErrorInfo {
+	return n.info
+}
+
+// Format implements fmt.Formatter using the wrapped error, so its
+// verbose formats are kept.
+func (n namespacedErr) Format(s fmt.State, verb rune) {
+	formatWrapped(s, verb, n.err)
+}
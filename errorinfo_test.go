@@ -0,0 +1,105 @@
+package lathos
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseErrorInfo(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		code string
+		exp  ErrorInfo
+		err  bool
+	}{
+		"domain and reason should parse": {
+			code: "users.example.com/USER_NOT_FOUND",
+			exp:  ErrorInfo{Domain: "users.example.com", Reason: "USER_NOT_FOUND"},
+		},
+		"reason without a domain should parse": {
+			code: "E404",
+			exp:  ErrorInfo{Reason: "E404"},
+		},
+		"empty domain should error": {
+			code: "/USER_NOT_FOUND",
+			err:  true,
+		},
+		"lower case reason should error": {
+			code: "users.example.com/user_not_found",
+			err:  true,
+		},
+		"reason starting with a digit should error": {
+			code: "404",
+			err:  true,
+		},
+		"reason ending with an underscore should error": {
+			code: "USER_",
+			err:  true,
+		},
+		"empty code should error": {
+			code: "",
+			err:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			info, err := ParseErrorInfo(test.code)
+			is.Equal(test.err, err != nil)
+			is.Equal(test.exp, info)
+			if err == nil {
+				is.Equal(test.code, info.String())
+			}
+		})
+	}
+}
+
+func TestErrorInfoOf(t *testing.T) {
+	t.Parallel()
+	info := ErrorInfo{
+		Domain:   "users.example.com",
+		Reason:   "USER_NOT_FOUND",
+		Metadata: map[string]string{"userId": "123"},
+	}
+	tests := map[string]struct {
+		err error
+		exp ErrorInfo
+		ok  bool
+	}{
+		"namespaced error should return its info": {
+			err: fmt.Errorf("wrap: %w", WithErrorInfo(&testCodedClientErr{code: "E404"}, info)),
+			exp: info,
+			ok:  true,
+		},
+		"structured code should be parsed": {
+			err: fmt.Errorf("wrap: %w", &testCodedClientErr{code: "payments.example.com/CARD_DECLINED"}),
+			exp: ErrorInfo{Domain: "payments.example.com", Reason: "CARD_DECLINED"},
+			ok:  true,
+		},
+		"invalid code should not be returned": {
+			err: &testCodedClientErr{code: "not found"},
+		},
+		"error without a code should not be returned": {
+			err: fmt.Errorf("oops"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			info, ok := ErrorInfoOf(test.err)
+			is.Equal(test.ok, ok)
+			is.Equal(test.exp, info)
+		})
+	}
+}
+
+func TestWithErrorInfo(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(WithErrorInfo(nil, ErrorInfo{Reason: "NIL"}))
+	err := WithErrorInfo(&testCodedClientErr{code: "E404"}, ErrorInfo{Reason: "USER_NOT_FOUND"})
+	is.True(IsNamespaced(err))
+	is.True(IsClientError(err))
+}
@@ -0,0 +1,295 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// Kind identifies one of the built-in error types that can be created with New.
+// The values match the behaviours used in an error catalog.
+type Kind string
+
+// Error kinds that can be created with New.
+const (
+	KindNotFound         Kind = "not_found"
+	KindDuplicate        Kind = "duplicate"
+	KindConflict         Kind = "conflict"
+	KindBadRequest       Kind = "bad_request"
+	KindNotAuthenticated Kind = "not_authenticated"
+	KindNotAuthorised    Kind = "not_authorised"
+	KindCannotProcess    Kind = "cannot_process"
+	KindTooManyRequests  Kind = "too_many_requests"
+	KindUnavailable      Kind = "unavailable"
+	KindTimeout          Kind = "timeout"
+	KindCanceled         Kind = "canceled"
+	KindInternal         Kind = "internal"
+	KindRetryable        Kind = "retryable"
+)
+
+// Option sets an optional value on an error created with New.
+type Option func(o *options)
+
+type options struct {
+	id         string
+	code       string
+	detail     string
+	cause      error
+	fields     map[string]interface{}
+	retryAfter time.Duration
+	retryable  bool
+	severity   *lathos.Level
+	extensions map[string]interface{}
+}
+
+// WithCode sets the code of the error, such as E404.
+func WithCode(code string) Option {
+	return func(o *options) {
+		o.code = code
+	}
+}
+
+// WithDetail sets the detail of a client error or the message of an internal error.
+func WithDetail(detail string) Option {
+	return func(o *options) {
+		o.detail = detail
+	}
+}
+
+// WithDetailf sets the detail of the error, formatted with args.
+func WithDetailf(format string, args ...interface{}) Option {
+	return func(o *options) {
+		o.detail = fmt.Sprintf(format, args...)
+	}
+}
+
+// WithID overrides the random ID given to the error, this could
+// be a correlation ID or a request ID.
+func WithID(id string) Option {
+	return func(o *options) {
+		o.id = id
+	}
+}
+
+// WithCause sets the error that caused this one, it is returned from Cause.
+// errors.Is and errors.As find errors in the cause but its lathos behaviours
+// are not visible, so a client error caused by an internal error is not an
+// internal error. For internal errors this is the original error that triggered the error.
+func WithCause(err error) Option {
+	return func(o *options) {
+		o.cause = err
+	}
+}
+
+// WithFields adds metadata to internal errors. Client errors shouldn't carry
+// debug information so ignore this option.
+func WithFields(fields map[string]interface{}) Option {
+	return func(o *options) {
+		if o.fields == nil {
+			o.fields = make(map[string]interface{}, len(fields))
+		}
+		for k, v := range fields {
+			o.fields[k] = v
+		}
+	}
+}
+
+// WithRetryAfter marks the error as lathos.Retryable and records how long the caller
+// should wait before retrying, this can be read with lathos.RetryAfterOf.
+func WithRetryAfter(d time.Duration) Option {
+	return func(o *options) {
+		o.retryable = true
+		o.retryAfter = d
+	}
+}
+
+// WithRetryable marks the error as lathos.Retryable.
+func WithRetryable() Option {
+	return func(o *options) {
+		o.retryable = true
+	}
+}
+
+// WithSeverity overrides the severity of the error, see lathos.WithSeverity.
+func WithSeverity(l lathos.Level) Option {
+	return func(o *options) {
+		o.severity = &l
+	}
+}
+
+// WithExtensions adds members that should be returned to a client along
+// with the error, such as a problem details response.
+func WithExtensions(ext map[string]interface{}) Option {
+	return func(o *options) {
+		if o.extensions == nil {
+			o.extensions = make(map[string]interface{}, len(ext))
+		}
+		for k, v := range ext {
+			o.extensions[k] = v
+		}
+	}
+}
+
+// New will create an error of the provided kind, configured by opts:
+//
+//	err := errs.New(errs.KindNotFound,
+//		errs.WithCode("U404"),
+//		errs.WithDetailf("user %s not found", id),
+//		errs.WithCause(err))
+//
+// This means new attributes can be added to errors without a constructor
+// for each. An unknown kind will return an internal error.
+func New(kind Kind, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var err error
+	switch kind {
+	case KindNotFound:
+		e := NewErrNotFound(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindDuplicate:
+		e := NewErrDuplicate(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindConflict:
+		e := NewErrConflict(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindBadRequest:
+		e := NewErrBadRequest(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindNotAuthenticated:
+		e := NewErrNotAuthenticated(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindNotAuthorised:
+		e := NewErrNotAuthorised(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindCannotProcess:
+		e := NewErrUnprocessable(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindTooManyRequests:
+		e := NewErrTooManyRequests(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindUnavailable:
+		e := NewErrNotAvailable(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindTimeout:
+		e := NewErrTimeout(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindCanceled:
+		e := NewErrCanceled(o.code, o.detail)
+		e.ErrClient = o.client(e.ErrClient)
+		err = e
+	case KindInternal:
+		err = o.internal()
+	case KindRetryable:
+		err = ErrRetryable{ErrInternal: o.internal()}
+	default:
+		o.cause = fmt.Errorf("unknown error kind %q", kind)
+		o.detail = ""
+		err = o.internal()
+	}
+	switch {
+	case o.retryAfter > 0:
+		err = retryAfterErr{retryableErr: retryableErr{err: err}, after: o.retryAfter}
+	case o.retryable && kind != KindRetryable:
+		err = retryableErr{err: err}
+	}
+	if o.severity != nil {
+		err = lathos.WithSeverity(err, *o.severity)
+	}
+	return err
+}
+
+// client applies the options to c.
+func (o *options) client(c ErrClient) ErrClient {
+	if o.id != "" {
+		c.id = o.id
+	}
+	if o.cause != nil || o.extensions != nil {
+		c.extra = &clientExtra{
+			cause:      o.cause,
+			extensions: o.extensions,
+		}
+	}
+	return c
+}
+
+// internal creates an ErrInternal from the options, if there's no cause one
+// is created from the detail. Unless the cause has a stack trace the stack of
+// the caller of New is recorded, internal must only be called from New.
+func (o *options) internal() *ErrInternal {
+	cause := o.cause
+	if cause == nil {
+		detail := o.detail
+		if detail == "" {
+			detail = "internal error"
+		}
+		cause = errors.New(detail)
+	}
+	e := newErrInternal(cause, o.code, 2)
+	if o.detail != "" {
+		e.message = o.detail
+	}
+	if o.id != "" {
+		e.id = o.id
+	}
+	for k, v := range o.fields {
+		e.metadata[k] = v
+	}
+	e.extensions = o.extensions
+	return e
+}
+
+// retryableErr marks an error as retryable.
+type retryableErr struct {
+	err error
+}
+
+// Error implements the error interface.
+func (r retryableErr) Error() string {
+	return r.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (r retryableErr) Unwrap() error {
+	return r.err
+}
+
+// Format implements fmt.Formatter using the wrapped error, so %+v
+// still prints its code, id and stack.
+func (r retryableErr) Format(s fmt.State, verb rune) {
+	if f, ok := r.err.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	formatError(s, verb, r.err.Error())
+}
+
+// Retryable implements lathos.Retryable.
+func (r retryableErr) Retryable() bool {
+	return true
+}
+
+// retryAfterErr marks an error as retryable after a duration.
+type retryAfterErr struct {
+	retryableErr
+	after time.Duration
+}
+
+// RetryAfter implements lathos.RetryAfter.
+func (r retryAfterErr) RetryAfter() time.Duration {
+	return r.after
+}
@@ -0,0 +1,178 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	cause := errors.New("sql: no rows in result set")
+	tests := map[string]struct {
+		kind  Kind
+		opts  []Option
+		check func(is *is.I, err error)
+	}{
+		"not found should be a client error with code and detail": {
+			kind: KindNotFound,
+			opts: []Option{WithCode("U404"), WithDetailf("user %s not found", "123"), WithID("req-1")},
+			check: func(is *is.I, err error) {
+				var c lathos.ClientError
+				is.True(errors.As(err, &c))
+				is.True(lathos.IsNotFound(err))
+				is.Equal(c.Code(), "U404")
+				is.Equal(c.Detail(), "user 123 not found")
+				is.Equal(c.ID(), "req-1")
+			},
+		},
+		"client error should unwrap to its cause": {
+			kind: KindConflict,
+			opts: []Option{WithCause(cause), WithExtensions(map[string]interface{}{"version": 2})},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsConflict(err))
+				is.True(errors.Is(err, cause))
+				var e ErrConflict
+				is.True(errors.As(err, &e))
+				is.Equal(e.Extensions(), map[string]interface{}{"version": 2})
+			},
+		},
+		"internal error should have fields and cause": {
+			kind: KindInternal,
+			opts: []Option{WithCode("I001"), WithCause(cause), WithFields(map[string]interface{}{"table": "users"})},
+			check: func(is *is.I, err error) {
+				var e *ErrInternal
+				is.True(errors.As(err, &e))
+				is.True(errors.Is(err, cause))
+				is.Equal(e.Cause(), cause)
+				is.Equal(e.Code(), "I001")
+				is.Equal(e.Metadata(), map[string]interface{}{"table": "users"})
+				is.Equal(e.Error(), cause.Error())
+			},
+		},
+		"internal error without a cause should use its detail": {
+			kind: KindInternal,
+			opts: []Option{WithDetail("failed to save")},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsInternalError(err))
+				is.Equal(err.Error(), "failed to save")
+			},
+		},
+		"retry after should be retryable": {
+			kind: KindTooManyRequests,
+			opts: []Option{WithRetryAfter(time.Minute)},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsTooManyRequests(err))
+				is.True(lathos.IsRetryable(err))
+				d, ok := lathos.RetryAfterOf(err)
+				is.True(ok)
+				is.Equal(d, time.Minute)
+			},
+		},
+		"retryable should not have a retry after": {
+			kind: KindUnavailable,
+			opts: []Option{WithRetryable()},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsUnavailable(err))
+				is.True(lathos.IsRetryable(err))
+				_, ok := lathos.RetryAfterOf(err)
+				is.True(!ok)
+			},
+		},
+		"severity should be overridden": {
+			kind: KindBadRequest,
+			opts: []Option{WithSeverity(lathos.LevelWarning)},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsBadRequest(err))
+				is.Equal(lathos.SeverityOf(err), lathos.LevelWarning)
+			},
+		},
+		"unknown kind should be internal": {
+			kind: Kind("teapot"),
+			opts: []Option{WithCode("T418")},
+			check: func(is *is.I, err error) {
+				is.True(lathos.IsInternalError(err))
+				is.True(errors.Is(err, Code("T418")))
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			test.check(is, New(test.kind, test.opts...))
+		})
+	}
+}
+
+func TestNew_Frames(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		kind Kind
+		opts []Option
+	}{
+		"internal error without a cause": {
+			kind: KindInternal,
+			opts: []Option{WithDetail("failed to save")},
+		},
+		"internal error with a cause without a stack": {
+			kind: KindInternal,
+			opts: []Option{WithCause(errors.New("boom"))},
+		},
+		"retryable error": {
+			kind: KindRetryable,
+		},
+		"unknown kind": {
+			kind: Kind("unknown"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			var e interface{ Frames() []Frame }
+			is.True(errors.As(New(test.kind, test.opts...), &e))
+			ff := e.Frames()
+			is.True(len(ff) > 0)
+			is.True(strings.Contains(ff[0].Function, "TestNew_Frames")) // the caller of New
+		})
+	}
+}
+
+func TestNew_Format(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err  error
+		code string
+	}{
+		"retryable error": {
+			err:  New(KindRetryable, WithCode("R001"), WithDetail("try again")),
+			code: "R001",
+		},
+		"retry after error": {
+			err:  New(KindTooManyRequests, WithCode("T001"), WithDetail("slow"), WithRetryAfter(time.Second)),
+			code: "T001",
+		},
+		"severity error": {
+			err:  lathos.WithSeverity(New(KindInternal, WithCode("I001")), lathos.LevelCritical),
+			code: "I001",
+		},
+		"namespaced error": {
+			err:  lathos.WithErrorInfo(New(KindNotFound, WithCode("U404")), lathos.ErrorInfo{Domain: "users.example.com", Reason: "USER_NOT_FOUND"}),
+			code: "U404",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(fmt.Sprintf("%v", test.err), test.err.Error())
+			verbose := fmt.Sprintf("%+v", test.err)
+			is.True(strings.Contains(verbose, "code: "+test.code))
+			is.True(strings.Contains(verbose, "id: "))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package errs
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// The errs types don't implement Unwrap, the cause of an error is returned
+// from Cause instead. errors.Is and errors.As still find errors in the cause
+// through the Is and As methods below, but errors with a lathos behaviour are
+// skipped so a cause never changes how its error is classified, for example an
+// internal error wrapping a client error is still only an internal error.
+
+// causeIs returns true if target is found in cause with errors.Is.
+func causeIs(cause, target error) bool {
+	return cause != nil && errors.Is(cause, target)
+}
+
+// causeAs finds the first error in cause that can be assigned to target, as
+// errors.As does, skipping any error with a lathos behaviour. The As method of
+// a skipped error is still used, so errors in the cause of an errs type are found.
+func causeAs(cause error, target interface{}) bool {
+	val := reflect.ValueOf(target)
+	if cause == nil || val.Kind() != reflect.Ptr || val.IsNil() {
+		return false
+	}
+	typ := val.Type().Elem()
+	var found bool
+	lathos.Walk(cause, func(err error, _ int) bool {
+		if !hasBehaviour(err) && reflect.TypeOf(err).AssignableTo(typ) {
+			val.Elem().Set(reflect.ValueOf(err))
+			found = true
+			return false
+		}
+		if a, ok := err.(interface{ As(interface{}) bool }); ok {
+			fresh := reflect.New(typ)
+			if a.As(fresh.Interface()) {
+				if v, ok := fresh.Elem().Interface().(error); !ok || !hasBehaviour(v) {
+					val.Elem().Set(fresh.Elem())
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// hasBehaviour returns true if err itself implements a lathos behaviour.
+// Timeout isn't checked as standard library errors, such as *net.OpError,
+// implement it and should still be found.
+func hasBehaviour(err error) bool {
+	switch err.(type) {
+	case lathos.ClientError, lathos.InternalError, lathos.NotFound, lathos.Duplicate,
+		lathos.NotAuthorised, lathos.NotAuthenticated, lathos.BadRequest, lathos.CannotProcess,
+		lathos.Unavailable, lathos.Retryable, lathos.RetryAfter, lathos.TooManyRequests,
+		lathos.Conflict, lathos.Canceled, lathos.Severity, lathos.Namespaced, lathos.Violations:
+		return true
+	}
+	return false
+}
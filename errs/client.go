@@ -2,8 +2,11 @@ package errs
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/google/uuid"
+
+	"github.com/theflyingcodr/lathos"
 )
 
 // ErrClient can be implemented to create an error
@@ -16,6 +19,17 @@ type ErrClient struct {
 	code   string
 	title  string
 	detail string
+	// template is set if the error was created from a Template.
+	template *Template
+	// extra holds optional values set by New, it is a pointer
+	// so ErrClient stays comparable.
+	extra *clientExtra
+}
+
+// clientExtra holds the optional values of an ErrClient.
+type clientExtra struct {
+	cause      error
+	extensions map[string]interface{}
 }
 
 func newErrClient(code, detail string) ErrClient {
@@ -30,7 +44,7 @@ func newErrClient(code, detail string) ErrClient {
 // If you implement your own errors this could be a correlation ID or
 // a request ID.
 // You could also override this value in an error handler when converting the
-// error to a response.
+// error to a response by using WithID.
 func (e ErrClient) ID() string {
 	return e.id
 }
@@ -61,6 +75,42 @@ func (e ErrClient) Error() string {
 	return e.title + ": " + e.detail
 }
 
+// Cause returns the cause of the error, if one was set with WithCause.
+// errors.Is and errors.As find errors in the cause, but not its behaviours.
+func (e ErrClient) Cause() error {
+	if e.extra == nil {
+		return nil
+	}
+	return e.extra.cause
+}
+
+// Extensions returns additional members that should be added to
+// a response for this error, if any were set with WithExtensions.
+func (e ErrClient) Extensions() map[string]interface{} {
+	if e.extra == nil {
+		return nil
+	}
+	return e.extra.extensions
+}
+
+// Is will return true if target is the Template this error was created
+// from, a Code matching the error code or is found in the cause, it is used by errors.Is.
+func (e ErrClient) Is(target error) bool {
+	switch t := target.(type) {
+	case *Template:
+		return e.template != nil && e.template == t
+	case lathos.CodeMatcher:
+		return t.Matches(e.code)
+	}
+	return causeIs(e.Cause(), target)
+}
+
+// As finds the first error in the cause matching target, errors with a lathos
+// behaviour are skipped, it is used by errors.As.
+func (e ErrClient) As(target interface{}) bool {
+	return causeAs(e.Cause(), target)
+}
+
 // ErrNotFound can be returned if something is accessed
 // that doesn't exist or has been deleted.
 type ErrNotFound struct {
@@ -366,3 +416,112 @@ func NewErrBadRequest(code, detail string) ErrBadRequest {
 func NewErrBadRequestf(code, detail string, a ...interface{}) ErrBadRequest {
 	return NewErrBadRequest(code, fmt.Sprintf(detail, a...))
 }
+
+// ErrPayloadTooLarge is a BadRequest error returned when a request body is
+// larger than the server accepts, it has a Status of 413 rather than 400.
+type ErrPayloadTooLarge struct {
+	ErrBadRequest
+	limit int64
+}
+
+// NewErrPayloadTooLarge will create and return a new PayloadTooLarge error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as B413.
+// Limit is the maximum number of bytes allowed in the body.
+func NewErrPayloadTooLarge(code, detail string, limit int64) ErrPayloadTooLarge {
+	e := NewErrBadRequest(code, detail)
+	e.title = "Payload too large"
+	return ErrPayloadTooLarge{
+		ErrBadRequest: e,
+		limit:         limit,
+	}
+}
+
+// Limit returns the maximum number of bytes allowed in the body.
+func (e ErrPayloadTooLarge) Limit() int64 {
+	return e.limit
+}
+
+// Status returns http.StatusRequestEntityTooLarge so the error is
+// written with a 413 status rather than the 400 of a BadRequest.
+func (e ErrPayloadTooLarge) Status() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// As sets target to the embedded ErrBadRequest if it is an *ErrBadRequest, so the
+// error can be handled as any other BadRequest, it is used by errors.As.
+func (e ErrPayloadTooLarge) As(target interface{}) bool {
+	if t, ok := target.(*ErrBadRequest); ok {
+		*t = e.ErrBadRequest
+		return true
+	}
+	return e.ErrBadRequest.As(target)
+}
+
+// ErrTimeout can be returned if an operation did not complete
+// in time, such as a database query or a call to another service.
+type ErrTimeout struct {
+	ErrClient
+}
+
+// NewErrTimeout will create and return a new Timeout error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as T001.
+// Detail can be supplied to give more context to the error, ie
+// "the payment service did not respond in time".
+func NewErrTimeout(code, detail string) ErrTimeout {
+	c := newErrClient(code, detail)
+	c.title = "Timeout"
+	return ErrTimeout{
+		ErrClient: c,
+	}
+}
+
+// NewErrTimeoutf will create and return a new Timeout error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as T001.
+// Detail can be supplied to give more context to the error, ie
+// "the payment service did not respond in time".
+func NewErrTimeoutf(code, detail string, a ...interface{}) ErrTimeout {
+	return NewErrTimeout(code, fmt.Sprintf(detail, a...))
+}
+
+// Timeout implements the Timeout interface and
+// is used in error type checks.
+func (e ErrTimeout) Timeout() bool {
+	return true
+}
+
+// ErrCanceled can be returned if an operation was cancelled,
+// usually because the client went away before it completed.
+type ErrCanceled struct {
+	ErrClient
+}
+
+// NewErrCanceled will create and return a new Canceled error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as C499.
+// Detail can be supplied to give more context to the error, ie
+// "the request was cancelled by the client".
+func NewErrCanceled(code, detail string) ErrCanceled {
+	c := newErrClient(code, detail)
+	c.title = "Request cancelled"
+	return ErrCanceled{
+		ErrClient: c,
+	}
+}
+
+// NewErrCanceledf will create and return a new Canceled error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as C499.
+// Detail can be supplied to give more context to the error, ie
+// "the request was cancelled by the client".
+func NewErrCanceledf(code, detail string, a ...interface{}) ErrCanceled {
+	return NewErrCanceled(code, fmt.Sprintf(detail, a...))
+}
+
+// Canceled implements the Canceled interface and
+// is used in error type checks.
+func (e ErrCanceled) Canceled() bool {
+	return true
+}
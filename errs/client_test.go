@@ -3,6 +3,7 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -118,3 +119,60 @@ func Test_FmtString(t *testing.T) {
 		})
 	}
 }
+
+func TestErrClient_Format(t *testing.T) {
+	t.Parallel()
+	err := NewErrNotFound("E404", "thing 123 not found")
+	tests := map[string]struct {
+		format string
+		exp    string
+	}{
+		"%v should match Error": {
+			format: "%v",
+			exp:    "Not found: thing 123 not found",
+		},
+		"%s should match Error": {
+			format: "%s",
+			exp:    "Not found: thing 123 not found",
+		},
+		"%q should quote Error": {
+			format: "%q",
+			exp:    `"Not found: thing 123 not found"`,
+		},
+		"%+v should print all fields": {
+			format: "%+v",
+			exp: "Not found: thing 123 not found\nid: " + err.ID() +
+				"\ncode: E404\ntitle: Not found\ndetail: thing 123 not found",
+		},
+		"%#v should print go syntax": {
+			format: "%#v",
+			exp: `errs.ErrNotFound{ID:"` + err.ID() +
+				`", Code:"E404", Title:"Not found", Detail:"thing 123 not found"}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, fmt.Sprintf(test.format, err))
+		})
+	}
+}
+
+func TestFormat_GoSyntaxType(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err error
+		exp string
+	}{
+		"client error": {err: newErrClient("C001", "detail"), exp: "errs.ErrClient{"},
+		"canceled":     {err: NewErrCanceled("C499", "detail"), exp: "errs.ErrCanceled{"},
+		"validation":   {err: NewErrValidation("V001", "detail"), exp: "errs.ErrValidation{"},
+		"retryable":    {err: NewErrRetryable(errors.New("boom"), "detail", "R001"), exp: "errs.ErrRetryable{"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.True(strings.HasPrefix(fmt.Sprintf("%#v", test.err), test.exp))
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package errs
+
+import "github.com/theflyingcodr/lathos"
+
+// Code is a matcher used with errors.Is to find an error with a code
+// in the error chain, errors.Is(err, errs.Code("E404")) is equivalent
+// to errors.Is(err, lathos.CodeMatcher("E404")).
+type Code = lathos.CodeMatcher
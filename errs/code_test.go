@@ -0,0 +1,63 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestCode_Is(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err    error
+		target error
+		exp    bool
+	}{
+		"client error with matching code should match": {
+			err:    NewErrNotFound("E404", "user not found"),
+			target: Code("E404"),
+			exp:    true,
+		},
+		"wrapped client error with matching code should match": {
+			err:    fmt.Errorf("handler: %w", pkgerrs.Wrap(NewErrDuplicate("D001", "exists"), "store")),
+			target: lathos.CodeMatcher("D001"),
+			exp:    true,
+		},
+		"client error with different code should not match": {
+			err:    NewErrNotFound("E404", "user not found"),
+			target: Code("E405"),
+			exp:    false,
+		},
+		"internal error with matching code should match": {
+			err:    pkgerrs.WithMessage(NewErrInternal(errors.New("boom"), "I500"), "store"),
+			target: Code("I500"),
+			exp:    true,
+		},
+		"retryable error with matching code should match": {
+			err:    NewErrRetryable(errors.New("boom"), "failed", "R001"),
+			target: Code("R001"),
+			exp:    true,
+		},
+		"empty code should not match an error without a code": {
+			err:    NewErrNotFound("", "user not found"),
+			target: Code(""),
+			exp:    false,
+		},
+		"standard error should not match": {
+			err:    errors.New("E404"),
+			target: Code("E404"),
+			exp:    false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, errors.Is(test.err, test.target))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Format implements fmt.Formatter.
+//
+//	%s, %v  the same as Error()
+//	%q      a quoted Error()
+//	%+v     a verbose view with each field on its own line
+//	%#v     a Go-syntax representation
+func (e ErrClient) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrClient")
+}
+
+// format implements Format for ErrClient and the types embedding it, name
+// is the type printed by %#v.
+func (e ErrClient) format(s fmt.State, verb rune, name string) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = fmt.Fprintf(s, "%s\nid: %s\ncode: %s\ntitle: %s\ndetail: %s", e.Error(), e.id, e.code, e.title, e.detail)
+		if c := e.Cause(); c != nil {
+			_, _ = io.WriteString(s, "\ncause:")
+			for ; c != nil; c = errors.Unwrap(c) {
+				_, _ = fmt.Fprintf(s, "\n\t%T: %s", c, c.Error())
+			}
+		}
+	case verb == 'v' && s.Flag('#'):
+		_, _ = fmt.Fprintf(s, "%s{ID:%q, Code:%q, Title:%q, Detail:%q}", name, e.id, e.code, e.title, e.detail)
+	default:
+		formatError(s, verb, e.Error())
+	}
+}
+
+// Format implements fmt.Formatter.
+//
+//	%s, %v  the same as Error()
+//	%q      a quoted Error()
+//	%+v     a verbose view with the id, code, message, metadata, stack and cause chain
+//	%#v     a Go-syntax representation
+func (e ErrInternal) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrInternal")
+}
+
+// format implements Format for ErrInternal and ErrRetryable, name
+// is the type printed by %#v.
+func (e ErrInternal) format(s fmt.State, verb rune, name string) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = fmt.Fprintf(s, "%s\nid: %s\ncode: %s\nmessage: %s", e.Error(), e.id, e.code, e.message)
+		if len(e.metadata) > 0 {
+			_, _ = io.WriteString(s, "\nmetadata:")
+			for _, k := range sortedKeys(e.metadata) {
+				_, _ = fmt.Fprintf(s, "\n\t%s: %v", k, e.metadata[k])
+			}
+		}
+		_, _ = io.WriteString(s, "\ncause:")
+		for c := e.err; c != nil; c = errors.Unwrap(c) {
+			_, _ = fmt.Fprintf(s, "\n\t%T: %s", c, c.Error())
+		}
+		if e.stack != "" {
+			_, _ = fmt.Fprintf(s, "\nstack:\n%s", e.stack)
+		}
+	case verb == 'v' && s.Flag('#'):
+		_, _ = fmt.Fprintf(s, "%s{ID:%q, Code:%q, Message:%q, Metadata:%#v, Err:%#v}",
+			name, e.id, e.code, e.message, e.metadata, e.err)
+	default:
+		formatError(s, verb, e.Error())
+	}
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrNotFound) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrNotFound")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrDuplicate) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrDuplicate")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrNotAuthenticated) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrNotAuthenticated")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrNotAuthorised) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrNotAuthorised")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrNotAvailable) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrNotAvailable")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrUnprocessable) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrUnprocessable")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrTooManyRequests) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrTooManyRequests")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrConflict) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrConflict")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrBadRequest) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrBadRequest")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrTimeout) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrTimeout")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrCanceled) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrCanceled")
+}
+
+// Format implements fmt.Formatter, see ErrClient.Format.
+func (e ErrValidation) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrValidation")
+}
+
+// Format implements fmt.Formatter, see ErrInternal.Format.
+func (e ErrRetryable) Format(s fmt.State, verb rune) {
+	e.format(s, verb, "errs.ErrRetryable")
+}
+
+// formatError handles the non verbose verbs shared by all error types.
+func formatError(s fmt.State, verb rune, msg string) {
+	switch verb {
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", msg)
+	default:
+		_, _ = io.WriteString(s, msg)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
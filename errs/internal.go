@@ -2,8 +2,11 @@ package errs
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/theflyingcodr/lathos"
 )
 
 // ErrInternal implements InternalError and can be used
@@ -16,7 +19,17 @@ type ErrInternal struct {
 	code     string
 	message  string
 	stack    string
+	pcs      []uintptr
 	metadata map[string]interface{}
+	// extensions are additional response members set with WithExtensions.
+	extensions map[string]interface{}
+
+	// runtime context, only set if CaptureRuntimeContext is enabled.
+	createdAt   time.Time
+	goroutineID uint64
+	hostname    string
+	version     string
+	revision    string
 }
 
 // NewErrInternal will create and return a new ErrInternal.
@@ -24,15 +37,27 @@ type ErrInternal struct {
 // so assumes you are using the /pkg/errors library to wrap
 // your errors.
 // You can implement your own.
+//
+// If CaptureRuntimeContext has been enabled the error will also record
+// when and where it was created.
 func NewErrInternal(err error, code string) *ErrInternal {
-	return &ErrInternal{
+	return newErrInternal(err, code, 1)
+}
+
+// newErrInternal creates an ErrInternal, skip is the number of frames
+// between this and the caller whose stack should be recorded.
+func newErrInternal(err error, code string, skip int) *ErrInternal {
+	e := &ErrInternal{
 		id:       uuid.New().String(),
 		message:  err.Error(),
 		err:      err,
 		code:     code,
 		stack:    fmt.Sprintf("%+v", err),
+		pcs:      callers(err, skip+2),
 		metadata: make(map[string]interface{}),
 	}
+	e.withRuntimeContext()
+	return e
 }
 
 // AddField assumes the underlying metadata map has been created and appends fields to it
@@ -59,14 +84,27 @@ func (e ErrInternal) Stack() string {
 	return e.stack
 }
 
+// Frames returns the stack trace as structured frames. This is taken from the deepest
+// pkg/errors stack trace in the wrapped error or, if there isn't one, from where
+// the error was created.
+// When DevelopmentMode is enabled each frame also contains the surrounding source lines
+// which can be printed using RenderFrames.
+func (e ErrInternal) Frames() []Frame {
+	return frames(e.pcs)
+}
+
 // Metadata is a data bag and can contain headers,
 // method, status code, uri etc.
 func (e ErrInternal) Metadata() map[string]interface{} {
 	return e.metadata
 }
 
-// Error implements the error interface.
+// Error implements the error interface, the underlying error is
+// appended if the message differs from it.
 func (e ErrInternal) Error() string {
+	if e.err == nil || e.message == e.err.Error() {
+		return e.message
+	}
 	return fmt.Sprintf("%s: %s", e.message, e.err)
 }
 
@@ -75,6 +113,65 @@ func (e ErrInternal) Code() string {
 	return e.code
 }
 
+// Cause returns the original error that triggered the error.
+// errors.Is and errors.As find errors in the cause, but not its behaviours,
+// so a client error in the cause isn't returned to a client.
+func (e ErrInternal) Cause() error {
+	return e.err
+}
+
+// As finds the first error in the cause matching target, errors with a lathos
+// behaviour are skipped, it is used by errors.As.
+func (e ErrInternal) As(target interface{}) bool {
+	return causeAs(e.err, target)
+}
+
+// Extensions returns additional members that should be added to
+// a response for this error, if any were set with WithExtensions.
+func (e ErrInternal) Extensions() map[string]interface{} {
+	return e.extensions
+}
+
+// Is will return true if target is a Code matching the error code or
+// is found in the cause, it is used by errors.Is.
+func (e ErrInternal) Is(target error) bool {
+	if c, ok := target.(lathos.CodeMatcher); ok {
+		return c.Matches(e.code)
+	}
+	return causeIs(e.err, target)
+}
+
+// CreatedAt returns the time the error was created in UTC, this is zero
+// unless CaptureRuntimeContext is enabled.
+func (e ErrInternal) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// GoroutineID returns the id of the goroutine the error was created on, this is zero
+// unless CaptureRuntimeContext is enabled.
+func (e ErrInternal) GoroutineID() uint64 {
+	return e.goroutineID
+}
+
+// Hostname returns the hostname of the machine the error was created on, this is empty
+// unless CaptureRuntimeContext is enabled.
+func (e ErrInternal) Hostname() string {
+	return e.hostname
+}
+
+// Version returns the module version of the binary that created the error, this
+// is "(devel)" for local builds and empty unless CaptureRuntimeContext is enabled.
+func (e ErrInternal) Version() string {
+	return e.version
+}
+
+// Revision returns the VCS revision the binary that created the error was built from,
+// this is empty unless CaptureRuntimeContext is enabled and the binary was built
+// with VCS stamping.
+func (e ErrInternal) Revision() string {
+	return e.revision
+}
+
 // ErrRetryable can be returned if you reach a condition
 // where an error occurred but it can be retried.
 type ErrRetryable struct {
@@ -0,0 +1,138 @@
+package errs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// TestErrInternal_RuntimeContext isn't parallel as it modifies the package level setting.
+func TestErrInternal_RuntimeContext(t *testing.T) {
+	tests := map[string]struct {
+		capture bool
+	}{
+		"runtime context should be recorded when enabled": {
+			capture: true,
+		},
+		"runtime context should be empty when disabled": {
+			capture: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			CaptureRuntimeContext(test.capture)
+			defer CaptureRuntimeContext(false)
+
+			err := NewErrInternal(errors.New("boom"), "I001")
+			_, ok := err.Metadata()[MetaHostname]
+			is.Equal(test.capture, ok)
+			is.Equal(test.capture, !err.CreatedAt().IsZero())
+			is.Equal(test.capture, err.GoroutineID() != 0)
+			if test.capture {
+				is.Equal(err.Metadata()[MetaGoroutineID], err.GoroutineID())
+				is.Equal(err.Metadata()[MetaVersion], err.Version())
+			}
+		})
+	}
+}
+
+// TestErrInternal_Frames isn't parallel as it modifies the package level setting.
+func TestErrInternal_Frames(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		dev       bool
+		expSource bool
+	}{
+		"frames should be taken from where the error was created": {
+			err: errors.New("boom"),
+		},
+		"frames should be taken from a pkg/errors stack": {
+			err: pkgerrs.New("boom"),
+		},
+		"frames should contain source in development mode": {
+			err:       errors.New("boom"),
+			dev:       true,
+			expSource: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			DevelopmentMode(test.dev)
+			defer DevelopmentMode(false)
+
+			ff := NewErrInternal(test.err, "I001").Frames()
+			is.True(len(ff) > 0)
+			is.True(strings.Contains(ff[0].Function, "TestErrInternal_Frames"))
+			is.True(strings.HasSuffix(ff[0].File, "internal_test.go"))
+			is.Equal(test.expSource, len(ff[0].Source) > 0)
+
+			var buf bytes.Buffer
+			is.NoErr(RenderFrames(&buf, ff[:1]))
+			is.Equal(test.expSource, strings.Contains(buf.String(), "NewErrInternal(test.err"))
+		})
+	}
+}
+
+func TestErrInternal_Format(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	cause := errors.New("connection refused")
+	err := NewErrInternal(fmt.Errorf("dial db: %w", cause), "I001").AddField("user", 123)
+
+	is.Equal(err.Error(), "dial db: connection refused")
+	is.Equal(fmt.Sprintf("%v", err), "dial db: connection refused")
+
+	verbose := fmt.Sprintf("%+v", err)
+	is.True(strings.Contains(verbose, "id: "+err.ID()))
+	is.True(strings.Contains(verbose, "code: I001"))
+	is.True(strings.Contains(verbose, "\tuser: 123"))
+	is.True(strings.Contains(verbose, "*fmt.wrapError: dial db: connection refused"))
+	is.True(strings.Contains(verbose, "*errors.errorString: connection refused"))
+
+	is.True(strings.HasPrefix(fmt.Sprintf("%#v", err), `errs.ErrInternal{ID:"`+err.ID()+`", Code:"I001"`))
+
+	retry := NewErrRetryable(cause, "try again", "R001")
+	is.Equal(retry.Error(), "Retryable error occurred: try again connection refused")
+}
+
+func TestErrInternal_Opaque(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	client := NewErrNotFound("E404", "secret")
+	err := NewErrInternal(client, "I001")
+
+	is.True(lathos.IsInternalError(err))
+	is.True(!lathos.IsClientError(err)) // the cause isn't unwrapped
+	is.True(!lathos.IsNotFound(err))
+	is.True(errors.Is(err, client)) // the cause is still found
+	var nf ErrNotFound
+	is.True(!errors.As(err, &nf))
+	is.Equal(err.Cause(), client)
+	is.Equal(lathos.SeverityOf(err), lathos.LevelError)
+}
+
+func TestErrClient_CauseOpaque(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	internal := NewErrInternal(&fs.PathError{Op: "open", Path: "/data", Err: fs.ErrNotExist}, "I001")
+	err := New(KindNotFound, WithCode("U404"), WithCause(internal))
+
+	is.True(lathos.IsNotFound(err))
+	is.True(!lathos.IsInternalError(err)) // the cause's behaviours aren't visible
+	is.Equal(lathos.SeverityOf(err), lathos.LevelInfo)
+	is.True(errors.Is(err, internal))
+	is.True(errors.Is(err, fs.ErrNotExist))
+	var pe *fs.PathError
+	is.True(errors.As(err, &pe))
+	is.Equal(pe.Path, "/data")
+}
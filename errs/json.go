@@ -0,0 +1,107 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/internal/hook"
+)
+
+// unknownFieldPrefix starts the error returned by a json.Decoder when
+// DisallowUnknownFields is set, there's no exported type for it.
+const unknownFieldPrefix = "json: unknown field "
+
+func init() {
+	hook.JSON = fromJSON
+}
+
+// fromJSON implements lathos.FromJSONDecode, it is here as lathos can't import this package.
+func fromJSON(err error, data []byte) error {
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxErr):
+		return NewErrPayloadTooLarge(lathos.CodeJSONTooLarge,
+			fmt.Sprintf("request body must not be larger than %d bytes", maxErr.Limit), maxErr.Limit)
+	case errors.As(err, &syntaxErr):
+		detail := fmt.Sprintf("request body contains invalid JSON at byte %d", syntaxErr.Offset)
+		if data != nil {
+			line, col := position(data, syntaxErr.Offset)
+			detail = fmt.Sprintf("request body contains invalid JSON at line %d, column %d (byte %d)", line, col, syntaxErr.Offset)
+		}
+		return NewErrBadRequest(lathos.CodeJSONSyntax, detail)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewErrBadRequest(lathos.CodeJSONIncomplete, "request body contains incomplete JSON")
+	case errors.Is(err, io.EOF):
+		return NewErrBadRequest(lathos.CodeJSONEmptyBody, "request body must not be empty")
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return NewErrBadRequest(lathos.CodeJSONTypeMismatch, fmt.Sprintf("request body must be %s, got %s", jsonType(typeErr.Type), typeErr.Value))
+		}
+		return NewErrValidation(lathos.CodeJSONTypeMismatch, "", lathos.Violation{
+			Field:   lathos.JSONPointer(strings.Split(typeErr.Field, ".")...),
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be %s, got %s", typeErr.Field, jsonType(typeErr.Type), typeErr.Value),
+			Code:    lathos.CodeJSONTypeMismatch,
+		})
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), unknownFieldPrefix), `"`)
+		return NewErrValidation(lathos.CodeJSONUnknownField, "", lathos.Violation{
+			Field:   lathos.JSONPointer(field),
+			Rule:    "unknown",
+			Message: fmt.Sprintf("%s is not a known field", field),
+			Code:    lathos.CodeJSONUnknownField,
+		})
+	}
+	return err
+}
+
+// position returns the 1 based line and column of the byte before offset in data,
+// a SyntaxError offset is the number of bytes read before the error.
+func position(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = len(before) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// jsonType returns the name of the JSON type t is decoded from, so
+// Go type names aren't exposed to clients.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	default:
+		return "a valid value"
+	}
+}
@@ -0,0 +1,147 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+type testJSONRequest struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Address struct {
+		City string `json:"city"`
+	} `json:"address"`
+}
+
+func TestFromJSONDecode(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		body       string
+		limit      int64
+		code       string
+		title      string
+		detail     string
+		violations []lathos.Violation
+		status     int
+	}{
+		"valid body should not error": {
+			body: `{"name":"alice"}`,
+		},
+		"syntax error should include the offset": {
+			body:   `{"name":}`,
+			code:   lathos.CodeJSONSyntax,
+			detail: "request body contains invalid JSON at byte 9",
+		},
+		"truncated body should be incomplete": {
+			body:   `{"name":"alice"`,
+			code:   lathos.CodeJSONIncomplete,
+			detail: "request body contains incomplete JSON",
+		},
+		"empty body should error": {
+			body:   ``,
+			code:   lathos.CodeJSONEmptyBody,
+			detail: "request body must not be empty",
+		},
+		"type mismatch should be a violation": {
+			body:   `{"address":{"city":10}}`,
+			code:   lathos.CodeJSONTypeMismatch,
+			detail: "/address/city: address.city must be a string, got number",
+			violations: []lathos.Violation{{
+				Field: "/address/city", Rule: "type", Message: "address.city must be a string, got number", Code: lathos.CodeJSONTypeMismatch,
+			}},
+		},
+		"wrong root type should be a mismatch": {
+			body:   `[1]`,
+			code:   lathos.CodeJSONTypeMismatch,
+			detail: "request body must be an object, got array",
+		},
+		"unknown field should be a violation": {
+			body:   `{"nmae":"alice"}`,
+			code:   lathos.CodeJSONUnknownField,
+			detail: "/nmae: nmae is not a known field",
+			violations: []lathos.Violation{{
+				Field: "/nmae", Rule: "unknown", Message: "nmae is not a known field", Code: lathos.CodeJSONUnknownField,
+			}},
+		},
+		"large body should be too large": {
+			body:   `{"name":"` + strings.Repeat("a", 100) + `"}`,
+			limit:  10,
+			code:   lathos.CodeJSONTooLarge,
+			title:  "Payload too large",
+			detail: "request body must not be larger than 10 bytes",
+			status: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			limit := test.limit
+			if limit == 0 {
+				limit = 1 << 20
+			}
+			body := http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(strings.NewReader(test.body)), limit)
+			dec := json.NewDecoder(body)
+			dec.DisallowUnknownFields()
+			var req testJSONRequest
+			err := lathos.FromJSONDecode(dec.Decode(&req))
+			if test.code == "" {
+				is.NoErr(err)
+				return
+			}
+			title := test.title
+			if title == "" {
+				title = "Bad Request"
+			}
+			var br ErrBadRequest
+			is.True(errors.As(err, &br))
+			is.True(lathos.IsBadRequest(err))
+			is.Equal(br.Code(), test.code)
+			is.Equal(br.Title(), title)
+			is.Equal(br.Detail(), test.detail)
+			is.Equal(lathos.ViolationsOf(err), test.violations)
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			if test.status != 0 {
+				var tl interface {
+					Status() int
+					Limit() int64
+				}
+				is.True(errors.As(err, &tl))
+				is.Equal(tl.Status(), test.status)
+				is.Equal(tl.Limit(), test.limit)
+			}
+		})
+	}
+}
+
+func TestFromJSONUnmarshal(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	data := []byte("{\n  \"name\": \"alice\",\n  \"age\": }")
+	var req testJSONRequest
+	err := lathos.FromJSONUnmarshal(json.Unmarshal(data, &req), data)
+	var c ErrBadRequest
+	is.True(errors.As(err, &c))
+	is.Equal(c.Code(), lathos.CodeJSONSyntax)
+	is.Equal(c.Detail(), "request body contains invalid JSON at line 3, column 10 (byte 31)")
+}
+
+func TestFromJSONDecode_PassThrough(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(lathos.FromJSONDecode(nil))
+	var req *testJSONRequest
+	err := json.NewDecoder(bytes.NewBufferString(`{}`)).Decode(req)
+	is.Equal(lathos.FromJSONDecode(err), err) // programming errors are unchanged
+	other := errors.New("boom")
+	is.Equal(lathos.FromJSONDecode(other), other)
+}
@@ -0,0 +1,93 @@
+package errs
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metadata keys used when runtime context is captured on an ErrInternal.
+const (
+	MetaCreatedAt   = "created_at"
+	MetaGoroutineID = "goroutine_id"
+	MetaHostname    = "hostname"
+	MetaVersion     = "version"
+	MetaRevision    = "vcs_revision"
+)
+
+// captureRuntime is toggled by CaptureRuntimeContext.
+var captureRuntime atomic.Bool //nolint:gochecknoglobals // toggled once at startup.
+
+// CaptureRuntimeContext when enabled will record the time, goroutine ID, hostname
+// and the module version and VCS revision of the running binary on every ErrInternal
+// created afterwards. This is disabled by default.
+//
+// The values are available from the ErrInternal accessors and in its Metadata.
+func CaptureRuntimeContext(enabled bool) {
+	captureRuntime.Store(enabled)
+}
+
+// buildContext is read once as it doesn't change for the life of the process.
+type buildContext struct {
+	hostname string
+	version  string
+	revision string
+}
+
+var build = struct { //nolint:gochecknoglobals // computed once per process.
+	once sync.Once
+	ctx  buildContext
+}{}
+
+func readBuildContext() buildContext {
+	build.once.Do(func() {
+		build.ctx.hostname, _ = os.Hostname()
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		build.ctx.version = info.Main.Version
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				build.ctx.revision = s.Value
+			}
+		}
+	})
+	return build.ctx
+}
+
+// withRuntimeContext will populate the runtime fields of e if capturing is enabled.
+func (e *ErrInternal) withRuntimeContext() {
+	if !captureRuntime.Load() {
+		return
+	}
+	b := readBuildContext()
+	e.createdAt = time.Now().UTC()
+	e.goroutineID = goroutineID()
+	e.hostname = b.hostname
+	e.version = b.version
+	e.revision = b.revision
+	e.metadata[MetaCreatedAt] = e.createdAt
+	e.metadata[MetaGoroutineID] = e.goroutineID
+	e.metadata[MetaHostname] = e.hostname
+	e.metadata[MetaVersion] = e.version
+	e.metadata[MetaRevision] = e.revision
+}
+
+// goroutineID parses the id of the current goroutine from the header
+// of its stack trace, "goroutine 123 [running]:".
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,171 @@
+package errs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	pkgerrs "github.com/pkg/errors"
+)
+
+// sourceContextLines is the number of lines shown either side of
+// the line a frame points at.
+const sourceContextLines = 3
+
+// development is toggled by DevelopmentMode.
+var development atomic.Bool //nolint:gochecknoglobals // toggled once at startup.
+
+// sourceCache holds the lines of source files already read from disk.
+var sourceCache = struct { //nolint:gochecknoglobals // shared by all errors to avoid re-reading files.
+	sync.RWMutex
+	files map[string][]string
+}{files: map[string][]string{}}
+
+// DevelopmentMode when enabled will add the surrounding source lines to each Frame
+// returned from ErrInternal.Frames. The source files are read from disk and cached
+// so this should only be enabled when running locally. This is disabled by default.
+func DevelopmentMode(enabled bool) {
+	development.Store(enabled)
+}
+
+// SourceLine is a single line of source code.
+type SourceLine struct {
+	Number int
+	Text   string
+}
+
+// Frame is a single frame of a stack trace.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+	// Source contains the lines around Line, it is only populated
+	// when DevelopmentMode is enabled and the file can be read.
+	Source []SourceLine
+}
+
+// stackTracer is implemented by errors created with the pkg/errors library.
+type stackTracer interface {
+	StackTrace() pkgerrs.StackTrace
+}
+
+// callers returns the program counters for the deepest stack trace found
+// in the error chain or, if there isn't one, the stack of the caller.
+func callers(err error, skip int) []uintptr {
+	var st pkgerrs.StackTrace
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if s, ok := e.(stackTracer); ok {
+			st = s.StackTrace()
+		}
+	}
+	if st != nil {
+		pcs := make([]uintptr, len(st))
+		for i, f := range st {
+			pcs[i] = uintptr(f)
+		}
+		return pcs
+	}
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(skip+1, pcs)]
+}
+
+// frames resolves program counters to Frames.
+func frames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	ff := make([]Frame, 0, len(pcs))
+	rf := runtime.CallersFrames(pcs)
+	for {
+		f, more := rf.Next()
+		fr := Frame{
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		}
+		if development.Load() {
+			fr.Source = sourceLines(f.File, f.Line)
+		}
+		ff = append(ff, fr)
+		if !more {
+			break
+		}
+	}
+	return ff
+}
+
+// sourceLines returns the lines around line in file, the file is read
+// once and then served from the cache.
+func sourceLines(file string, line int) []SourceLine {
+	sourceCache.RLock()
+	lines, ok := sourceCache.files[file]
+	sourceCache.RUnlock()
+	if !ok {
+		lines = readLines(file)
+		sourceCache.Lock()
+		sourceCache.files[file] = lines
+		sourceCache.Unlock()
+	}
+	if line < 1 || line > len(lines) {
+		return nil
+	}
+	from, to := line-sourceContextLines, line+sourceContextLines
+	if from < 1 {
+		from = 1
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+	src := make([]SourceLine, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		src = append(src, SourceLine{Number: n, Text: lines[n-1]})
+	}
+	return src
+}
+
+// readLines reads all lines of a file, nil is returned if it cannot be read
+// so we don't keep trying to read a missing file.
+func readLines(file string) []string {
+	f, err := os.Open(file) //nolint:gosec // file paths come from the runtime, not user input.
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	return lines
+}
+
+// RenderFrames will write frames to w in a human readable format, if a frame
+// has source lines these are printed below it with the failing line marked:
+//
+//	main.handler
+//		/src/app/handler.go:42
+//		   41 |	id := r.URL.Query().Get("id")
+//		>  42 |	return errs.NewErrInternal(err, "I001")
+//		   43 | }
+func RenderFrames(w io.Writer, frames []Frame) error {
+	for _, f := range frames {
+		if _, err := fmt.Fprintf(w, "%s\n\t%s:%d\n", f.Function, f.File, f.Line); err != nil {
+			return err
+		}
+		for _, l := range f.Source {
+			marker := " "
+			if l.Number == f.Line {
+				marker = ">"
+			}
+			if _, err := fmt.Fprintf(w, "\t%s %4d | %s\n", marker, l.Number, l.Text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package errs
+
+import (
+	"fmt"
+)
+
+// Template is a declarative sentinel used to create errors with the same code
+// and detail format. Each error created has its own ID but errors.Is will match
+// any error created from the template, regardless of its ID or arguments:
+//
+//	var ErrUserNotFound = errs.NotFoundTemplate("U404", "user %s not found")
+//
+//	func Get(id string) error {
+//		return ErrUserNotFound.New(id)
+//	}
+//
+//	if errors.Is(err, ErrUserNotFound) {
+//		// handle missing user
+//	}
+type Template struct {
+	code   string
+	format string
+	build  func(t *Template, detail string) error
+}
+
+func newTemplate(code, format string, build func(t *Template, detail string) error) *Template {
+	return &Template{
+		code:   code,
+		format: format,
+		build:  build,
+	}
+}
+
+// New will create a new error from the template, args are used to format the detail.
+func (t *Template) New(args ...interface{}) error {
+	detail := t.format
+	if len(args) > 0 {
+		detail = fmt.Sprintf(t.format, args...)
+	}
+	return t.build(t, detail)
+}
+
+// Code returns the code of errors created from the template.
+func (t *Template) Code() string {
+	return t.code
+}
+
+// Error implements the error interface so a Template can be
+// used as the target of errors.Is.
+func (t *Template) Error() string {
+	return t.code + ": " + t.format
+}
+
+// NotFoundTemplate will create a Template for ErrNotFound errors.
+func NotFoundTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrNotFound(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// DuplicateTemplate will create a Template for ErrDuplicate errors.
+func DuplicateTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrDuplicate(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// NotAuthenticatedTemplate will create a Template for ErrNotAuthenticated errors.
+func NotAuthenticatedTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrNotAuthenticated(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// NotAuthorisedTemplate will create a Template for ErrNotAuthorised errors.
+func NotAuthorisedTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrNotAuthorised(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// NotAvailableTemplate will create a Template for ErrNotAvailable errors.
+func NotAvailableTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrNotAvailable(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// UnprocessableTemplate will create a Template for ErrUnprocessable errors.
+func UnprocessableTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrUnprocessable(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// TooManyRequestsTemplate will create a Template for ErrTooManyRequests errors.
+func TooManyRequestsTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrTooManyRequests(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// ConflictTemplate will create a Template for ErrConflict errors.
+func ConflictTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrConflict(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// BadRequestTemplate will create a Template for ErrBadRequest errors.
+func BadRequestTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrBadRequest(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// TimeoutTemplate will create a Template for ErrTimeout errors.
+func TimeoutTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrTimeout(code, detail)
+		e.template = t
+		return e
+	})
+}
+
+// CanceledTemplate will create a Template for ErrCanceled errors.
+func CanceledTemplate(code, format string) *Template {
+	return newTemplate(code, format, func(t *Template, detail string) error {
+		e := NewErrCanceled(code, detail)
+		e.template = t
+		return e
+	})
+}
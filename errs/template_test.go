@@ -0,0 +1,75 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+var (
+	errTestNotFound  = NotFoundTemplate("U404", "user %s not found")   //nolint:gochecknoglobals // test sentinel.
+	errTestNotFound2 = NotFoundTemplate("U404", "user %s not found")   //nolint:gochecknoglobals // test sentinel.
+	errTestConflict  = ConflictTemplate("C001", "version %d is stale") //nolint:gochecknoglobals // test sentinel.
+)
+
+func TestTemplate_Is(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err    error
+		target error
+		exp    bool
+	}{
+		"error should match its template": {
+			err:    errTestNotFound.New("123"),
+			target: errTestNotFound,
+			exp:    true,
+		},
+		"wrapped error should match its template": {
+			err:    pkgerrs.Wrap(fmt.Errorf("get user: %w", errTestNotFound.New("456")), "handler"),
+			target: errTestNotFound,
+			exp:    true,
+		},
+		"error should not match a different template with the same code": {
+			err:    errTestNotFound.New("123"),
+			target: errTestNotFound2,
+			exp:    false,
+		},
+		"error should not match a template of another kind": {
+			err:    errTestNotFound.New("123"),
+			target: errTestConflict,
+			exp:    false,
+		},
+		"error not created from a template should not match": {
+			err:    NewErrNotFound("U404", "user 123 not found"),
+			target: errTestNotFound,
+			exp:    false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, errors.Is(test.err, test.target))
+		})
+	}
+}
+
+func TestTemplate_New(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	err1 := errTestConflict.New(2)
+	err2 := errTestConflict.New(3)
+	is.True(lathos.IsConflict(err1))
+	is.Equal(err1.Error(), "Conflict: version 2 is stale")
+
+	var c1, c2 lathos.ClientError
+	is.True(errors.As(err1, &c1))
+	is.True(errors.As(err2, &c2))
+	is.True(c1.ID() != c2.ID())
+	is.Equal(c1.Code(), errTestConflict.Code())
+	is.Equal(DuplicateTemplate("D001", "exists").New().Error(), "Item already exists: exists")
+}
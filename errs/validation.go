@@ -0,0 +1,106 @@
+package errs
+
+import (
+	"fmt"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// ErrValidation is a BadRequest error that also lists each field
+// that failed validation so a client can highlight them.
+type ErrValidation struct {
+	ErrBadRequest
+	violations []lathos.Violation
+}
+
+// NewErrValidation will create and return a new Validation error.
+// You can supply a code which can be set in your application to identify
+// a particular error in code such as V001.
+// If detail is empty a summary of the violations is used.
+func NewErrValidation(code, detail string, violations ...lathos.Violation) ErrValidation {
+	if detail == "" {
+		detail = validationDetail(violations)
+	}
+	return ErrValidation{
+		ErrBadRequest: NewErrBadRequest(code, detail),
+		violations:    violations,
+	}
+}
+
+// Violations implements the Violations interface, a copy is returned
+// so the error can't be modified.
+func (e ErrValidation) Violations() []lathos.Violation {
+	vv := make([]lathos.Violation, len(e.violations))
+	copy(vv, e.violations)
+	return vv
+}
+
+// As sets target to the embedded ErrBadRequest if it is an *ErrBadRequest, so the
+// error can be handled as any other BadRequest, it is used by errors.As.
+func (e ErrValidation) As(target interface{}) bool {
+	if t, ok := target.(*ErrBadRequest); ok {
+		*t = e.ErrBadRequest
+		return true
+	}
+	return e.ErrBadRequest.As(target)
+}
+
+func validationDetail(violations []lathos.Violation) string {
+	switch len(violations) {
+	case 0:
+		return "the request is invalid"
+	case 1:
+		return violations[0].Field + ": " + violations[0].Message
+	default:
+		return fmt.Sprintf("%d fields are invalid", len(violations))
+	}
+}
+
+// Collector is used to build a Validation error field by field:
+//
+//	c := errs.NewCollector("V001")
+//	if req.Name == "" {
+//		c.Add("/name", "required", "name is required")
+//	}
+//	if err := c.Err(); err != nil {
+//		return err
+//	}
+type Collector struct {
+	code       string
+	violations []lathos.Violation
+}
+
+// NewCollector will create a Collector, code is used for the error it returns.
+func NewCollector(code string) *Collector {
+	return &Collector{code: code}
+}
+
+// Add will record a violation of rule for field in a fluent manner.
+func (c *Collector) Add(field, rule, message string) *Collector {
+	return c.AddViolation(lathos.Violation{Field: field, Rule: rule, Message: message})
+}
+
+// Addf will record a violation of rule for field with a formatted message.
+func (c *Collector) Addf(field, rule, message string, a ...interface{}) *Collector {
+	return c.Add(field, rule, fmt.Sprintf(message, a...))
+}
+
+// AddViolation will record v.
+func (c *Collector) AddViolation(v ...lathos.Violation) *Collector {
+	c.violations = append(c.violations, v...)
+	return c
+}
+
+// Len returns the number of violations recorded.
+func (c *Collector) Len() int {
+	return len(c.violations)
+}
+
+// Err returns a Validation error with the recorded violations or
+// nil if there are none.
+func (c *Collector) Err() error {
+	if len(c.violations) == 0 {
+		return nil
+	}
+	return NewErrValidation(c.code, "", c.violations...)
+}
@@ -0,0 +1,72 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		collect func(c *Collector)
+		exp     []lathos.Violation
+		detail  string
+	}{
+		"no violations should not error": {
+			collect: func(c *Collector) {},
+		},
+		"single violation should be used as the detail": {
+			collect: func(c *Collector) {
+				c.Add("/name", "required", "name is required")
+			},
+			exp:    []lathos.Violation{{Field: "/name", Rule: "required", Message: "name is required"}},
+			detail: "/name: name is required",
+		},
+		"multiple violations should be summarised": {
+			collect: func(c *Collector) {
+				c.Add("/name", "required", "name is required").
+					Addf("/age", "min", "age must be at least %d", 18).
+					AddViolation(lathos.Violation{Field: "/email", Rule: "email", Message: "email is invalid", Code: "V002"})
+			},
+			exp: []lathos.Violation{
+				{Field: "/name", Rule: "required", Message: "name is required"},
+				{Field: "/age", Rule: "min", Message: "age must be at least 18"},
+				{Field: "/email", Rule: "email", Message: "email is invalid", Code: "V002"},
+			},
+			detail: "3 fields are invalid",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			c := NewCollector("V001")
+			test.collect(c)
+			is.Equal(c.Len(), len(test.exp))
+			err := c.Err()
+			if test.exp == nil {
+				is.NoErr(err)
+				return
+			}
+			err = pkgerrs.Wrap(err, "validate")
+			is.True(lathos.IsBadRequest(err))
+			is.Equal(lathos.ViolationsOf(err), test.exp)
+			var e ErrValidation
+			is.True(pkgerrs.As(err, &e))
+			is.Equal(e.Code(), "V001")
+			is.Equal(e.Detail(), test.detail)
+		})
+	}
+}
+
+func TestErrValidation_WithID(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	e := NewErrValidation("V001", "invalid", lathos.Violation{Field: "/name", Message: "required"})
+	cp := e.WithID("req-1")
+	is.Equal(cp.ID(), "req-1")
+	is.Equal(cp.Violations(), e.Violations())
+}
@@ -0,0 +1,269 @@
+package errs
+
+// The With methods return a modified copy of an error, the original is
+// unchanged so errors can be safely shared, for example an error handler
+// can stamp the request ID onto an error returned from deep in the stack:
+//
+//	var nf errs.ErrNotFound
+//	if errors.As(err, &nf) {
+//		err = nf.WithID(requestID)
+//	}
+//
+// Each client error type has its own methods so the copy keeps its
+// concrete type and behaviours.
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrClient) WithID(id string) ErrClient {
+	e.id = id
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrClient) WithDetail(detail string) ErrClient {
+	e.detail = detail
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code. If the
+// code changes the copy no longer matches the Template it was created from.
+func (e ErrClient) WithCode(code string) ErrClient {
+	if code != e.code {
+		e.template = nil
+	}
+	e.code = code
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrNotFound) WithID(id string) ErrNotFound {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrNotFound) WithDetail(detail string) ErrNotFound {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrNotFound) WithCode(code string) ErrNotFound {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrDuplicate) WithID(id string) ErrDuplicate {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrDuplicate) WithDetail(detail string) ErrDuplicate {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrDuplicate) WithCode(code string) ErrDuplicate {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrNotAuthenticated) WithID(id string) ErrNotAuthenticated {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrNotAuthenticated) WithDetail(detail string) ErrNotAuthenticated {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrNotAuthenticated) WithCode(code string) ErrNotAuthenticated {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrNotAuthorised) WithID(id string) ErrNotAuthorised {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrNotAuthorised) WithDetail(detail string) ErrNotAuthorised {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrNotAuthorised) WithCode(code string) ErrNotAuthorised {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrNotAvailable) WithID(id string) ErrNotAvailable {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrNotAvailable) WithDetail(detail string) ErrNotAvailable {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrNotAvailable) WithCode(code string) ErrNotAvailable {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrUnprocessable) WithID(id string) ErrUnprocessable {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrUnprocessable) WithDetail(detail string) ErrUnprocessable {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrUnprocessable) WithCode(code string) ErrUnprocessable {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrTooManyRequests) WithID(id string) ErrTooManyRequests {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrTooManyRequests) WithDetail(detail string) ErrTooManyRequests {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrTooManyRequests) WithCode(code string) ErrTooManyRequests {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrConflict) WithID(id string) ErrConflict {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrConflict) WithDetail(detail string) ErrConflict {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrConflict) WithCode(code string) ErrConflict {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrBadRequest) WithID(id string) ErrBadRequest {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrBadRequest) WithDetail(detail string) ErrBadRequest {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrBadRequest) WithCode(code string) ErrBadRequest {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrPayloadTooLarge) WithID(id string) ErrPayloadTooLarge {
+	e.ErrBadRequest = e.ErrBadRequest.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrPayloadTooLarge) WithDetail(detail string) ErrPayloadTooLarge {
+	e.ErrBadRequest = e.ErrBadRequest.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrPayloadTooLarge) WithCode(code string) ErrPayloadTooLarge {
+	e.ErrBadRequest = e.ErrBadRequest.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrTimeout) WithID(id string) ErrTimeout {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrTimeout) WithDetail(detail string) ErrTimeout {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrTimeout) WithCode(code string) ErrTimeout {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrCanceled) WithID(id string) ErrCanceled {
+	e.ErrClient = e.ErrClient.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrCanceled) WithDetail(detail string) ErrCanceled {
+	e.ErrClient = e.ErrClient.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrCanceled) WithCode(code string) ErrCanceled {
+	e.ErrClient = e.ErrClient.WithCode(code)
+	return e
+}
+
+// WithID returns a copy of the error with its ID replaced by id.
+func (e ErrValidation) WithID(id string) ErrValidation {
+	e.ErrBadRequest = e.ErrBadRequest.WithID(id)
+	return e
+}
+
+// WithDetail returns a copy of the error with its detail replaced by detail.
+func (e ErrValidation) WithDetail(detail string) ErrValidation {
+	e.ErrBadRequest = e.ErrBadRequest.WithDetail(detail)
+	return e
+}
+
+// WithCode returns a copy of the error with its code replaced by code.
+func (e ErrValidation) WithCode(code string) ErrValidation {
+	e.ErrBadRequest = e.ErrBadRequest.WithCode(code)
+	return e
+}
@@ -0,0 +1,80 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestErrClient_With(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err  lathos.ClientError
+		with func(err error) lathos.ClientError
+		is   func(err error) bool
+	}{
+		"not found should keep its type": {
+			err: NewErrNotFound("U404", "user not found"),
+			with: func(err error) lathos.ClientError {
+				var e ErrNotFound
+				if !errors.As(err, &e) {
+					return nil
+				}
+				return e.WithID("req-1").WithCode("U405").WithDetail("gone")
+			},
+			is: lathos.IsNotFound,
+		},
+		"bad request should keep its type": {
+			err: NewErrBadRequest("B001", "name required"),
+			with: func(err error) lathos.ClientError {
+				var e ErrBadRequest
+				if !errors.As(err, &e) {
+					return nil
+				}
+				return e.WithID("req-1").WithCode("U405").WithDetail("gone")
+			},
+			is: lathos.IsBadRequest,
+		},
+		"too many requests should keep its type": {
+			err: NewErrTooManyRequests("T001", "slow down"),
+			with: func(err error) lathos.ClientError {
+				var e ErrTooManyRequests
+				if !errors.As(err, &e) {
+					return nil
+				}
+				return e.WithID("req-1").WithCode("U405").WithDetail("gone")
+			},
+			is: lathos.IsTooManyRequests,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			id := test.err.ID()
+			got := test.with(pkgerrs.Wrap(test.err, "handler"))
+			is.True(got != nil)
+			is.True(test.is(got))
+			is.Equal(got.ID(), "req-1")
+			is.Equal(got.Code(), "U405")
+			is.Equal(got.Detail(), "gone")
+			is.Equal(got.Title(), test.err.Title())
+			// the original is unchanged.
+			is.Equal(test.err.ID(), id)
+			is.True(test.err.Code() != "U405")
+		})
+	}
+}
+
+func TestErrClient_WithKeepsTemplate(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	var e ErrNotFound
+	is.True(errors.As(errTestNotFound.New("123"), &e))
+	is.True(errors.Is(e.WithID("req-1"), errTestNotFound))
+	is.True(errors.Is(e.WithCode(e.Code()), errTestNotFound))
+	is.True(!errors.Is(e.WithCode("OTHER"), errTestNotFound)) // a new code is a different error
+}
@@ -15,7 +15,14 @@ func main() {
 	fmt.Println(lathos.IsNotFound(e))
 	fmt.Println(lathos.IsClientError(e))
 
-	fmt.Printf("%+v\n", Test())
+	// the verbose verb prints every structured field of a lathos error,
+	// errors.As is used to find it as wrappers only print their message.
+	var nf errs.ErrNotFound
+	if errors.As(Test(), &nf) {
+		fmt.Printf("%+v\n", nf)
+	}
+
+	fmt.Printf("%+v\n", errs.NewErrInternal(errors.New("database unreachable"), "I001").AddField("user", 123))
 }
 
 // Test does nothing.
@@ -0,0 +1,4 @@
+// Package usererrs is an example of errors generated from a catalog file by lathos-gen.
+package usererrs
+
+//go:generate go run github.com/theflyingcodr/lathos/cmd/lathos-gen -in errors.yaml
@@ -0,0 +1,99 @@
+// Code generated by lathos-gen. DO NOT EDIT.
+
+package usererrs
+
+import (
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// Error codes defined in the catalog.
+const (
+	CodeUserNotFound    = "U404"
+	CodeUserExists      = "U409"
+	CodeUserRateLimited = "U429"
+	CodeUserStoreFailed = "U500"
+)
+
+// Catalog contains every error code defined in this package.
+var Catalog = catalog.New()
+
+func init() {
+	Catalog.MustRegister(
+		catalog.Entry{
+			Code:      CodeUserNotFound,
+			Behaviour: catalog.NotFound,
+			Title:     "User not found",
+			Detail:    "user %s not found",
+			Status:    0,
+			Docs:      "Returned when a user does not exist or has been deleted.",
+			Name:      "UserNotFound",
+			Causes: []string{
+				"The user id is incorrect.",
+				"The user has been deleted.",
+			},
+			Remediation: "Check the user id, deleted users cannot be restored.",
+			Params: []catalog.Param{
+				{Name: "userID", Type: "string"},
+			},
+		},
+		catalog.Entry{
+			Code:      CodeUserExists,
+			Behaviour: catalog.Duplicate,
+			Title:     "User already exists",
+			Detail:    "a user with email %s already exists",
+			Status:    0,
+			Docs:      "",
+			Name:      "UserExists",
+			Params: []catalog.Param{
+				{Name: "email", Type: "string"},
+			},
+		},
+		catalog.Entry{
+			Code:      CodeUserRateLimited,
+			Behaviour: catalog.TooManyRequests,
+			Title:     "",
+			Detail:    "user %s has made more than %d requests",
+			Status:    0,
+			Docs:      "",
+			Name:      "UserRateLimited",
+			Params: []catalog.Param{
+				{Name: "userID", Type: "string"},
+				{Name: "limit", Type: "int"},
+			},
+		},
+		catalog.Entry{
+			Code:      CodeUserStoreFailed,
+			Behaviour: catalog.Internal,
+			Title:     "",
+			Detail:    "failed to store user: %v",
+			Status:    0,
+			Docs:      "",
+			Name:      "UserStoreFailed",
+			Params: []catalog.Param{
+				{Name: "cause", Type: "error"},
+			},
+		},
+	)
+}
+
+// ErrUserNotFound returns a new U404 error.
+//
+// Returned when a user does not exist or has been deleted.
+func ErrUserNotFound(userID string) error {
+	return Catalog.New(CodeUserNotFound, userID)
+}
+
+// ErrUserExists returns a new U409 error.
+func ErrUserExists(email string) error {
+	return Catalog.New(CodeUserExists, email)
+}
+
+// ErrUserRateLimited returns a new U429 error.
+func ErrUserRateLimited(userID string, limit int) error {
+	return Catalog.New(CodeUserRateLimited, userID, limit)
+}
+
+// ErrUserStoreFailed returns a new U500 error.
+func ErrUserStoreFailed(cause error) error {
+	return Catalog.New(CodeUserStoreFailed, cause)
+}
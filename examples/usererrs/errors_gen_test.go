@@ -0,0 +1,71 @@
+// Code generated by lathos-gen. DO NOT EDIT.
+
+package usererrs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+func TestErrUserNotFound(t *testing.T) {
+	t.Parallel()
+	err := ErrUserNotFound("test")
+	if !lathos.IsNotFound(err) {
+		t.Fatalf("expected not_found error, got %v", err)
+	}
+	e, ok := Catalog.Lookup(CodeUserNotFound)
+	if !ok {
+		t.Fatal("expected U404 to be registered")
+	}
+	if e.Behaviour != catalog.NotFound {
+		t.Fatalf("expected behaviour not_found, got %s", e.Behaviour)
+	}
+}
+
+func TestErrUserExists(t *testing.T) {
+	t.Parallel()
+	err := ErrUserExists("test")
+	if !lathos.IsDuplicate(err) {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+	e, ok := Catalog.Lookup(CodeUserExists)
+	if !ok {
+		t.Fatal("expected U409 to be registered")
+	}
+	if e.Behaviour != catalog.Duplicate {
+		t.Fatalf("expected behaviour duplicate, got %s", e.Behaviour)
+	}
+}
+
+func TestErrUserRateLimited(t *testing.T) {
+	t.Parallel()
+	err := ErrUserRateLimited("test", 1)
+	if !lathos.IsTooManyRequests(err) {
+		t.Fatalf("expected too_many_requests error, got %v", err)
+	}
+	e, ok := Catalog.Lookup(CodeUserRateLimited)
+	if !ok {
+		t.Fatal("expected U429 to be registered")
+	}
+	if e.Behaviour != catalog.TooManyRequests {
+		t.Fatalf("expected behaviour too_many_requests, got %s", e.Behaviour)
+	}
+}
+
+func TestErrUserStoreFailed(t *testing.T) {
+	t.Parallel()
+	err := ErrUserStoreFailed(errors.New("test"))
+	if !lathos.IsInternalError(err) {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+	e, ok := Catalog.Lookup(CodeUserStoreFailed)
+	if !ok {
+		t.Fatal("expected U500 to be registered")
+	}
+	if e.Behaviour != catalog.Internal {
+		t.Fatalf("expected behaviour internal, got %s", e.Behaviour)
+	}
+}
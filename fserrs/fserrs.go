@@ -0,0 +1,61 @@
+// Package fserrs translates io/fs and os errors into lathos errors:
+//
+//	f, err := os.Open(filepath.Join(root, key))
+//	if err != nil {
+//		return fserrs.Translate(err)
+//	}
+//
+// The original error, usually a *fs.PathError, is kept as the cause so
+// errors.Is(err, fs.ErrNotExist) still works, but the path is never used
+// in the detail so the layout of the filesystem isn't exposed to clients.
+package fserrs
+
+import (
+	"errors"
+	"io/fs"
+	"syscall"
+
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Codes of the errors returned by Translate.
+const (
+	CodeNotExist   = "FS_NOT_EXIST"
+	CodeExist      = "FS_EXIST"
+	CodePermission = "FS_PERMISSION"
+	CodeNoSpace    = "FS_NO_SPACE"
+	CodeTemporary  = "FS_TEMPORARY"
+)
+
+// Translate maps err to a lathos error:
+//
+//	fs.ErrNotExist    NotFound
+//	fs.ErrExist       Duplicate
+//	fs.ErrPermission  NotAuthorised
+//	ENOSPC            Unavailable
+//	EAGAIN, EINTR     Retryable
+//
+// Any other error, including nil, is returned unchanged.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return errs.New(errs.KindNotFound, errs.WithCode(CodeNotExist),
+			errs.WithDetail("file not found"), errs.WithCause(err))
+	case errors.Is(err, fs.ErrExist):
+		return errs.New(errs.KindDuplicate, errs.WithCode(CodeExist),
+			errs.WithDetail("file already exists"), errs.WithCause(err))
+	case errors.Is(err, fs.ErrPermission):
+		return errs.New(errs.KindNotAuthorised, errs.WithCode(CodePermission),
+			errs.WithDetail("permission denied"), errs.WithCause(err))
+	case errors.Is(err, syscall.ENOSPC):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeNoSpace),
+			errs.WithDetail("storage is full"), errs.WithCause(err))
+	case errors.Is(err, syscall.EAGAIN), errors.Is(err, syscall.EINTR):
+		return errs.New(errs.KindRetryable, errs.WithCode(CodeTemporary),
+			errs.WithDetail("file operation was interrupted"), errs.WithCause(err))
+	}
+	return err
+}
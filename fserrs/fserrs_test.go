@@ -0,0 +1,91 @@
+package fserrs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	if err := os.Mkdir(secret, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		err   error
+		code  string
+		check func(err error) bool
+		retry bool
+	}{
+		"missing file should be not found": {
+			err: func() error {
+				_, err := os.Open(filepath.Join(secret, "missing.txt"))
+				return err
+			}(),
+			code:  CodeNotExist,
+			check: lathos.IsNotFound,
+		},
+		"existing file should be a duplicate": {
+			err:   os.Mkdir(secret, 0o700),
+			code:  CodeExist,
+			check: lathos.IsDuplicate,
+		},
+		"permission error should be not authorised": {
+			err:   &fs.PathError{Op: "open", Path: filepath.Join(secret, "key"), Err: syscall.EACCES},
+			code:  CodePermission,
+			check: lathos.IsNotAuthorised,
+		},
+		"no space should be unavailable": {
+			err:   &fs.PathError{Op: "write", Path: filepath.Join(secret, "key"), Err: syscall.ENOSPC},
+			code:  CodeNoSpace,
+			check: lathos.IsUnavailable,
+		},
+		"EAGAIN should be retryable": {
+			err:   &fs.PathError{Op: "read", Path: filepath.Join(secret, "key"), Err: syscall.EAGAIN},
+			code:  CodeTemporary,
+			check: lathos.IsInternalError,
+			retry: true,
+		},
+		"EINTR should be retryable": {
+			err:   &fs.PathError{Op: "read", Path: filepath.Join(secret, "key"), Err: syscall.EINTR},
+			code:  CodeTemporary,
+			check: lathos.IsInternalError,
+			retry: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.True(test.err != nil)
+			err := Translate(test.err)
+			is.True(test.check(err))
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			is.Equal(lathos.IsRetryable(err), test.retry)
+			is.True(errors.Is(err, test.err)) // original is kept as the cause
+			var pe *fs.PathError
+			is.True(errors.As(err, &pe))
+			var c lathos.ClientError
+			if errors.As(err, &c) {
+				is.True(!strings.Contains(c.Detail(), secret)) // the path is not exposed
+			}
+		})
+	}
+}
+
+func TestTranslate_PassThrough(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(Translate(nil))
+	other := errors.New("boom")
+	is.Equal(Translate(other), other)
+}
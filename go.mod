@@ -6,4 +6,5 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/matryer/is v1.4.0
 	github.com/pkg/errors v0.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
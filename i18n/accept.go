@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage will return the languages in an Accept-Language header
+// ordered by preference. Languages with a quality of 0 and the * wildcard are dropped.
+//
+//	ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5") // [fr-CH fr en]
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var ww []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.TrimSpace(fields[0])
+		if lang == "" || lang == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ww = append(ww, weighted{lang: lang, q: q})
+	}
+	sort.SliceStable(ww, func(i, j int) bool {
+		return ww[i].q > ww[j].q
+	})
+	langs := make([]string, 0, len(ww))
+	for _, w := range ww {
+		langs = append(langs, w.lang)
+	}
+	return langs
+}
@@ -0,0 +1,218 @@
+// Package i18n translates the title and detail of lathos errors.
+//
+// Translations are held in a Bundle, keyed by language and then by error code or
+// behaviour. The code is tried first so a specific code can override the message
+// for its behaviour:
+//
+//	b := i18n.NewBundle("en")
+//	b.Add("de", "not_found", i18n.Message{Title: "Nicht gefunden"})
+//	b.Add("de", "U404", i18n.Message{Title: "Benutzer nicht gefunden", Detail: "Benutzer {userID} wurde nicht gefunden"})
+//
+// Details can contain named params, ie {userID}, which are replaced with the values
+// returned from the error's Params() method, catalog errors implement this.
+//
+// Setting the bundle as the problem.Writer Localizer will translate
+// problems to the language requested in the Accept-Language header.
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	pkgerrs "github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// Message is a translated title and detail, either can be left empty
+// to use the error's own value.
+type Message struct {
+	Title  string `json:"title,omitempty" yaml:"title,omitempty"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// Parameterised is implemented by errors that can supply named params
+// to render translated details, such as catalog.Error.
+type Parameterised interface {
+	Params() map[string]interface{}
+}
+
+// Bundle contains the messages for each language, it is safe for concurrent use.
+type Bundle struct {
+	mu        sync.RWMutex
+	fallback  string
+	chains    map[string][]string
+	languages map[string]map[string]Message
+}
+
+// NewBundle will create a new Bundle, fallback is the language used
+// when none of the requested languages have a message.
+func NewBundle(fallback string) *Bundle {
+	return &Bundle{
+		fallback:  normalise(fallback),
+		chains:    map[string][]string{},
+		languages: map[string]map[string]Message{},
+	}
+}
+
+// Add will add a message for a language, key is an error code or a catalog.Behaviour.
+func (b *Bundle) Add(lang, key string, m Message) {
+	b.AddMessages(lang, map[string]Message{key: m})
+}
+
+// AddMessages will add messages for a language keyed by error code or catalog.Behaviour.
+func (b *Bundle) AddMessages(lang string, mm map[string]Message) {
+	lang = normalise(lang)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.languages[lang] == nil {
+		b.languages[lang] = map[string]Message{}
+	}
+	for k, m := range mm {
+		b.languages[lang][k] = m
+	}
+}
+
+// Load will read messages for a language in YAML or JSON from r, the file
+// is a map of error code or behaviour to message:
+//
+//	not_found:
+//	  title: Nicht gefunden
+//	U404:
+//	  title: Benutzer nicht gefunden
+//	  detail: Benutzer {userID} wurde nicht gefunden
+func (b *Bundle) Load(lang string, r io.Reader) error {
+	var mm map[string]Message
+	if err := yaml.NewDecoder(r).Decode(&mm); err != nil && !pkgerrs.Is(err, io.EOF) {
+		return pkgerrs.Wrapf(err, "failed to decode %s messages", lang)
+	}
+	b.AddMessages(lang, mm)
+	return nil
+}
+
+// LoadFile will open the file at path and Load the messages for a language.
+func (b *Bundle) LoadFile(lang, path string) error {
+	f, err := os.Open(path) //nolint:gosec // path is supplied by the developer.
+	if err != nil {
+		return pkgerrs.Wrapf(err, "failed to open %s messages %s", lang, path)
+	}
+	defer f.Close()
+	return b.Load(lang, f)
+}
+
+// SetFallbacks will set the languages tried, in order, when lang has no message.
+// By default a regional language falls back to its base language, ie
+// de-AT falls back to de, this can be used to add other languages such as
+// falling back from pt-BR to pt-PT.
+// The bundle's fallback language is always tried last.
+func (b *Bundle) SetFallbacks(lang string, fallbacks ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	chain := make([]string, 0, len(fallbacks))
+	for _, f := range fallbacks {
+		chain = append(chain, normalise(f))
+	}
+	b.chains[normalise(lang)] = chain
+}
+
+// Localize will return the message for err in the first of langs which has one,
+// falling back through each language's fallbacks and finally the bundle's fallback
+// language. The language used is returned, if no message is found ok is false.
+//
+// Messages are looked up by the error's code and then its behaviour. A detail that
+// contains params the error cannot supply is left empty so the error's own detail
+// can be used.
+func (b *Bundle) Localize(err error, langs ...string) (m Message, lang string, ok bool) {
+	code := codeOf(err)
+	behaviour := string(catalog.BehaviourOf(err))
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, l := range b.chain(langs) {
+		mm := b.languages[l]
+		if m, ok = mm[code]; !ok {
+			m, ok = mm[behaviour]
+		}
+		if ok {
+			m.Detail = render(m.Detail, err)
+			return m, l, true
+		}
+	}
+	return Message{}, "", false
+}
+
+// chain returns every language to try for the requested languages in order.
+func (b *Bundle) chain(langs []string) []string {
+	seen := map[string]struct{}{}
+	var chain []string
+	add := func(l string) {
+		if _, ok := seen[l]; !ok && l != "" {
+			seen[l] = struct{}{}
+			chain = append(chain, l)
+		}
+	}
+	for _, l := range langs {
+		l = normalise(l)
+		add(l)
+		for _, f := range b.chains[l] {
+			add(f)
+		}
+		if i := strings.IndexByte(l, '-'); i > 0 {
+			add(l[:i])
+		}
+	}
+	add(b.fallback)
+	return chain
+}
+
+// paramPattern matches a named param, ie {userID}.
+var paramPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`) //nolint:gochecknoglobals // compiled once.
+
+// render replaces the named params in detail with those from err, an empty string
+// is returned if any param can't be found.
+func render(detail string, err error) string {
+	if !strings.Contains(detail, "{") {
+		return detail
+	}
+	var params map[string]interface{}
+	var p Parameterised
+	if pkgerrs.As(err, &p) {
+		params = p.Params()
+	}
+	missing := false
+	out := paramPattern.ReplaceAllStringFunc(detail, func(s string) string {
+		v, ok := params[s[1:len(s)-1]]
+		if !ok {
+			missing = true
+			return s
+		}
+		return fmt.Sprint(v)
+	})
+	if missing {
+		return ""
+	}
+	return out
+}
+
+// normalise lower cases a language tag and uses - as the separator, ie en_GB becomes en-gb.
+func normalise(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+// codeOf returns the code of the first client or internal error in the chain.
+func codeOf(err error) string {
+	var ce lathos.ClientError
+	if pkgerrs.As(err, &ce) {
+		return ce.Code()
+	}
+	var ie lathos.InternalError
+	if pkgerrs.As(err, &ie) {
+		return ie.Code()
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+func TestBundle_Localize(t *testing.T) {
+	t.Parallel()
+	cat := catalog.New()
+	cat.MustRegister(catalog.Entry{
+		Code: "U404", Behaviour: catalog.NotFound, Detail: "user %s not found",
+		Params: []catalog.Param{{Name: "userID", Type: "string"}},
+	})
+	b := NewBundle("en")
+	b.AddMessages("de", map[string]Message{
+		"not_found": {Title: "Nicht gefunden"},
+		"U404":      {Title: "Benutzer nicht gefunden", Detail: "Benutzer {userID} wurde nicht gefunden"},
+		"D001":      {Detail: "Eintrag {id} existiert bereits"},
+	})
+	b.Add("pt-PT", "not_found", Message{Title: "Não encontrado"})
+	b.Add("en", "internal", Message{Title: "Something went wrong"})
+	b.SetFallbacks("pt-BR", "pt-PT")
+	tests := map[string]struct {
+		err     error
+		langs   []string
+		expMsg  Message
+		expLang string
+		expOK   bool
+	}{
+		"code message should be used with params": {
+			err:     cat.New("U404", "123"),
+			langs:   []string{"de"},
+			expMsg:  Message{Title: "Benutzer nicht gefunden", Detail: "Benutzer 123 wurde nicht gefunden"},
+			expLang: "de",
+			expOK:   true,
+		},
+		"behaviour message should be used when code has none": {
+			err:     errs.NewErrNotFound("X404", "thing not found"),
+			langs:   []string{"de"},
+			expMsg:  Message{Title: "Nicht gefunden"},
+			expLang: "de",
+			expOK:   true,
+		},
+		"regional language should fall back to base language": {
+			err:     errs.NewErrNotFound("X404", "thing not found"),
+			langs:   []string{"de-AT"},
+			expMsg:  Message{Title: "Nicht gefunden"},
+			expLang: "de",
+			expOK:   true,
+		},
+		"explicit fallback should be used": {
+			err:     errs.NewErrNotFound("X404", "thing not found"),
+			langs:   []string{"pt_BR"},
+			expMsg:  Message{Title: "Não encontrado"},
+			expLang: "pt-pt",
+			expOK:   true,
+		},
+		"languages should be tried in order": {
+			err:     errs.NewErrNotFound("X404", "thing not found"),
+			langs:   []string{"fr", "pt-PT", "de"},
+			expMsg:  Message{Title: "Não encontrado"},
+			expLang: "pt-pt",
+			expOK:   true,
+		},
+		"missing params should leave detail empty": {
+			err:     errs.NewErrDuplicate("D001", "item exists"),
+			langs:   []string{"de"},
+			expMsg:  Message{},
+			expLang: "de",
+			expOK:   true,
+		},
+		"bundle fallback should be used last": {
+			err:     errors.New("boom"),
+			langs:   []string{"de"},
+			expMsg:  Message{Title: "Something went wrong"},
+			expLang: "en",
+			expOK:   true,
+		},
+		"no message should not be ok": {
+			err:   errs.NewErrConflict("C001", "conflict"),
+			langs: []string{"de"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			m, lang, ok := b.Localize(test.err, test.langs...)
+			is.Equal(test.expOK, ok)
+			is.Equal(test.expLang, lang)
+			is.Equal(test.expMsg, m)
+		})
+	}
+}
+
+func TestBundle_Load(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	b := NewBundle("en")
+	is.NoErr(b.Load("fr", strings.NewReader("not_found:\n  title: Introuvable\n")))
+	m, _, ok := b.Localize(errs.NewErrNotFound("", ""), "fr-FR")
+	is.True(ok)
+	is.Equal(m.Title, "Introuvable")
+	is.True(b.Load("fr", strings.NewReader("not_found: [")) != nil)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		header string
+		exp    []string
+	}{
+		"empty header should return no languages": {
+			header: "",
+			exp:    []string{},
+		},
+		"languages should be ordered by quality": {
+			header: "en;q=0.8, fr-CH, de;q=0.9, *;q=0.5",
+			exp:    []string{"fr-CH", "de", "en"},
+		},
+		"zero quality should be dropped": {
+			header: "en, fr;q=0",
+			exp:    []string{"en"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, ParseAcceptLanguage(test.header))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package hook lets the errs package provide functions to the lathos package,
+// which can't import it as errs depends on lathos.
+package hook
+
+// JSON translates an error from decoding a JSON request body, data is the
+// JSON if it is known. It is set by the errs package and used by lathos.FromJSONDecode.
+var JSON func(err error, data []byte) error //nolint:gochecknoglobals // set once by the errs package.
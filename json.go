@@ -0,0 +1,47 @@
+package lathos
+
+import "github.com/theflyingcodr/lathos/internal/hook"
+
+// Codes of the errors returned by FromJSONDecode.
+const (
+	CodeJSONSyntax       = "JSON_SYNTAX"
+	CodeJSONIncomplete   = "JSON_INCOMPLETE"
+	CodeJSONTypeMismatch = "JSON_TYPE_MISMATCH"
+	CodeJSONUnknownField = "JSON_UNKNOWN_FIELD"
+	CodeJSONEmptyBody    = "JSON_EMPTY_BODY"
+	CodeJSONTooLarge     = "JSON_TOO_LARGE"
+)
+
+// FromJSONDecode translates an error returned from json.Decoder.Decode or json.Unmarshal
+// when decoding a request body into an errs.ErrBadRequest with a clear code and a
+// detail that is safe to return to a client:
+//
+//	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+//		return lathos.FromJSONDecode(err)
+//	}
+//
+// Syntax errors include the byte offset, type mismatches and unknown fields
+// are an errs.ErrValidation with a violation for the field. Bodies larger than
+// the limit set with http.MaxBytesReader are an errs.ErrPayloadTooLarge.
+//
+// Errors that aren't caused by the request body, such as passing a non-pointer
+// to Decode, are returned unchanged.
+//
+// The errors are created by the errs package, as this package can't import it,
+// so errs must be imported by the program, errors are returned unchanged if it isn't.
+func FromJSONDecode(err error) error {
+	return fromJSON(err, nil)
+}
+
+// FromJSONUnmarshal is the same as FromJSONDecode but uses data, the JSON that was
+// unmarshalled, to add the line and column to syntax errors.
+func FromJSONUnmarshal(err error, data []byte) error {
+	return fromJSON(err, data)
+}
+
+func fromJSON(err error, data []byte) error {
+	if err == nil || hook.JSON == nil {
+		return err
+	}
+	return hook.JSON(err, data)
+}
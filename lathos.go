@@ -1,6 +1,11 @@
 package lathos
 
 import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
 	"github.com/pkg/errors"
 )
 
@@ -146,6 +151,22 @@ func IsRetryable(err error) bool {
 	return errors.As(err, &t)
 }
 
+// RetryAfter can be implemented by errors that know how long a caller
+// should wait before re-submitting, such as a rate limit.
+type RetryAfter interface {
+	RetryAfter() time.Duration
+}
+
+// RetryAfterOf returns the duration of the first RetryAfter error in the chain,
+// false is returned if there isn't one.
+func RetryAfterOf(err error) (time.Duration, bool) {
+	var t RetryAfter
+	if !errors.As(err, &t) {
+		return 0, false
+	}
+	return t.RetryAfter(), true
+}
+
 // TooManyRequests when implemented will indicate that too many
 // requests have occurred and the system cannot handle any further requests.
 type TooManyRequests interface {
@@ -169,3 +190,193 @@ func IsConflict(err error) bool {
 	var t Conflict
 	return errors.As(err, &t)
 }
+
+// Timeout when implemented will indicate that an operation did not
+// complete in time, such as a database query or call to another service.
+// This matches net.Error so network timeouts are also Timeout errors.
+type Timeout interface {
+	Timeout() bool
+}
+
+// IsTimeout will check if this is a timeout error. Unlike the other behaviours
+// Timeout() must also return true, as standard library errors such as
+// *fs.PathError implement it but are only sometimes a timeout.
+func IsTimeout(err error) bool {
+	var found bool
+	Walk(err, func(err error, _ int) bool {
+		if t, ok := err.(Timeout); ok && t.Timeout() {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// Canceled when implemented will indicate that an operation was cancelled,
+// usually because the client went away, so it isn't a fault of the server.
+type Canceled interface {
+	Canceled() bool
+}
+
+// IsCanceled will check if this is a cancelled error.
+func IsCanceled(err error) bool {
+	var t Canceled
+	return errors.As(err, &t)
+}
+
+// Level describes how severe an error is, it can be used by loggers
+// to select a log level and by reporters to decide if someone should be paged.
+type Level int
+
+// Severity levels, ordered from least to most severe.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarning
+	LevelError
+	LevelCritical
+)
+
+// String returns the lowercase name of the level, ie "warning".
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarning:
+		return "warning"
+	case LevelError:
+		return "error"
+	case LevelCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// Severity when implemented will override the default severity derived
+// from the other behaviours an error implements.
+type Severity interface {
+	Severity() Level
+}
+
+// codeSeverities holds severity overrides keyed by error code.
+var codeSeverities = struct { //nolint:gochecknoglobals // overrides are process wide by design.
+	sync.RWMutex
+	levels map[string]Level
+}{levels: map[string]Level{}}
+
+// SetCodeSeverity will override the severity of every error with the provided code,
+// this is useful when a particular code, such as a payment failure, should always page.
+// A Severity implemented on the error itself takes precedence over this.
+func SetCodeSeverity(code string, l Level) {
+	codeSeverities.Lock()
+	defer codeSeverities.Unlock()
+	codeSeverities.levels[code] = l
+}
+
+// ResetCodeSeverity will remove an override set with SetCodeSeverity, errors with
+// the code then have the severity derived from their behaviours.
+func ResetCodeSeverity(code string) {
+	codeSeverities.Lock()
+	defer codeSeverities.Unlock()
+	delete(codeSeverities.levels, code)
+}
+
+// SeverityOf will return the severity of an error.
+//
+// If the error, or an error it wraps, implements Severity that is used, then any
+// override registered for its code with SetCodeSeverity, otherwise the level is derived
+// from its behaviours: client and Canceled errors are Info, Retryable, TooManyRequests and Timeout errors
+// are Warning, and InternalError, Unavailable and unknown errors are Error.
+func SeverityOf(err error) Level {
+	if err == nil {
+		return LevelDebug
+	}
+	var s Severity
+	if errors.As(err, &s) {
+		return s.Severity()
+	}
+	if code := codeOf(err); code != "" {
+		codeSeverities.RLock()
+		l, ok := codeSeverities.levels[code]
+		codeSeverities.RUnlock()
+		if ok {
+			return l
+		}
+	}
+	switch {
+	case IsCanceled(err):
+		return LevelInfo
+	case IsUnavailable(err):
+		return LevelError
+	case IsRetryable(err), IsTooManyRequests(err), IsTimeout(err):
+		return LevelWarning
+	case IsClientError(err):
+		return LevelInfo
+	}
+	return LevelError
+}
+
+// WithSeverity will wrap err so that it reports the provided severity
+// regardless of its behaviours, all other behaviours are still
+// available through the wrapped error.
+func WithSeverity(err error, l Level) error {
+	if err == nil {
+		return nil
+	}
+	return severityErr{err: err, level: l}
+}
+
+type severityErr struct {
+	err   error
+	level Level
+}
+
+// Error implements the error interface.
+func (s severityErr) Error() string {
+	return s.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (s severityErr) Unwrap() error {
+	return s.err
+}
+
+// Severity implements the Severity interface.
+func (s severityErr) Severity() Level {
+	return s.level
+}
+
+// Format implements fmt.Formatter using the wrapped error, so its
+// verbose formats are kept.
+func (s severityErr) Format(st fmt.State, verb rune) {
+	formatWrapped(st, verb, s.err)
+}
+
+// formatWrapped formats err with the verb used on an error wrapping it.
+func formatWrapped(s fmt.State, verb rune, err error) {
+	if f, ok := err.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	if verb == 'q' {
+		_, _ = fmt.Fprintf(s, "%q", err.Error())
+		return
+	}
+	_, _ = io.WriteString(s, err.Error())
+}
+
+// codeOf returns the code of the first ClientError or InternalError found
+// in the error chain.
+func codeOf(err error) string {
+	var c ClientError
+	if errors.As(err, &c) {
+		return c.Code()
+	}
+	var i InternalError
+	if errors.As(err, &i) {
+		return i.Code()
+	}
+	return ""
+}
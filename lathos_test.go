@@ -396,3 +396,162 @@ func TestIsInternalError(t *testing.T) {
 		})
 	}
 }
+
+type testCodedClientErr struct {
+	testClientErr
+	code string
+}
+
+func (t testCodedClientErr) Code() string {
+	return t.code
+}
+
+type testRetryable struct {
+	testInternalErr
+}
+
+func (t testRetryable) Retryable() bool {
+	return true
+}
+
+// TestSeverityOf isn't parallel as it modifies the code severity overrides.
+func TestSeverityOf(t *testing.T) {
+	codeSeverities.RLock()
+	prev, ok := codeSeverities.levels["P001"]
+	codeSeverities.RUnlock()
+	t.Cleanup(func() {
+		if ok {
+			SetCodeSeverity("P001", prev)
+			return
+		}
+		ResetCodeSeverity("P001")
+	})
+	SetCodeSeverity("P001", LevelCritical)
+	tests := map[string]struct {
+		err error
+		exp Level
+	}{
+		"client error should default to info": {
+			err: &testNotFound{},
+			exp: LevelInfo,
+		}, "wrapped client error should default to info": {
+			err: pkgerrs.Wrap(&testDuplicate{}, "wrapped error"),
+			exp: LevelInfo,
+		}, "internal error should default to error": {
+			err: &testInternalErr{},
+			exp: LevelError,
+		}, "retryable error should default to warning": {
+			err: &testRetryable{},
+			exp: LevelWarning,
+		}, "unavailable error should default to error": {
+			err: &testUnavailable{},
+			exp: LevelError,
+		}, "error not implementing any behaviour should default to error": {
+			err: errors.New("standard error"),
+			exp: LevelError,
+		}, "code override should be used": {
+			err: &testCodedClientErr{code: "P001"},
+			exp: LevelCritical,
+		}, "unregistered code should use default": {
+			err: &testCodedClientErr{code: "P002"},
+			exp: LevelInfo,
+		}, "error severity should take precedence over code": {
+			err: WithSeverity(&testCodedClientErr{code: "P001"}, LevelDebug),
+			exp: LevelDebug,
+		}, "error severity should override default": {
+			err: fmt.Errorf("my error %w", WithSeverity(&testNotFound{}, LevelWarning)),
+			exp: LevelWarning,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, SeverityOf(test.err))
+		})
+	}
+}
+
+func TestWithSeverity(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	err := WithSeverity(&testNotFound{}, LevelCritical)
+	is.True(IsNotFound(err))
+	is.True(IsClientError(err))
+	is.Equal(LevelCritical.String(), "critical")
+	is.Equal(WithSeverity(nil, LevelError), nil)
+}
+
+type testTimeout struct {
+	testInternalErr
+}
+
+func (t testTimeout) Timeout() bool {
+	return true
+}
+
+type testNotTimeout struct {
+	testInternalErr
+}
+
+func (t testNotTimeout) Timeout() bool {
+	return false
+}
+
+func TestIsTimeout(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.True(IsTimeout(pkgerrs.Wrap(&testTimeout{}, "wrapped error")))
+	is.True(!IsTimeout(&testNotTimeout{}))
+	is.True(!IsTimeout(errors.New("standard error")))
+	is.True(IsTimeout(fmt.Errorf("wrap %w: %w", &testNotTimeout{}, &testTimeout{})))
+	is.Equal(SeverityOf(&testTimeout{}), LevelWarning)
+}
+
+type testCanceled struct {
+	testClientErr
+}
+
+func (t testCanceled) Canceled() bool {
+	return true
+}
+
+func TestIsTimeoutAndCanceled(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err         error
+		expTimeout  bool
+		expCanceled bool
+		expSeverity Level
+	}{
+		"timeout error should return true for timeout": {
+			err:         pkgerrs.Wrap(&testTimeout{}, "wrapped error"),
+			expTimeout:  true,
+			expSeverity: LevelWarning,
+		}, "canceled error should return true for canceled": {
+			err:         fmt.Errorf("my error %w", &testCanceled{}),
+			expCanceled: true,
+			expSeverity: LevelInfo,
+		}, "error not implementing interface should return false": {
+			err:         errors.New("standard error"),
+			expSeverity: LevelError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.expTimeout, IsTimeout(test.err))
+			is.Equal(test.expCanceled, IsCanceled(test.err))
+			is.Equal(test.expSeverity, SeverityOf(test.err))
+		})
+	}
+}
+
+// TestResetCodeSeverity isn't parallel as it modifies the code severity overrides.
+func TestResetCodeSeverity(t *testing.T) {
+	is := is.New(t)
+	err := &testCodedClientErr{code: "P002"}
+	SetCodeSeverity("P002", LevelCritical)
+	is.Equal(SeverityOf(err), LevelCritical)
+	ResetCodeSeverity("P002")
+	is.Equal(SeverityOf(err), LevelInfo)
+}
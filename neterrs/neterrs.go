@@ -0,0 +1,90 @@
+// Package neterrs translates net and net/http client errors into lathos
+// errors so a retry layer knows whether a failed call to a dependency
+// can be retried:
+//
+//	resp, err := client.Do(req)
+//	if err != nil {
+//		return neterrs.Translate(err)
+//	}
+//
+// A *url.Error is unwrapped and only its cause kept, so the URL, which may
+// contain credentials or tokens, is not part of the translated error.
+package neterrs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Codes of the errors returned by Translate.
+const (
+	CodeTimeout      = "NET_TIMEOUT"
+	CodeConnRefused  = "NET_CONN_REFUSED"
+	CodeConnReset    = "NET_CONN_RESET"
+	CodeDNSTemporary = "NET_DNS_TEMPORARY"
+	CodeTLS          = "NET_TLS"
+)
+
+// Translate maps err to a lathos error:
+//
+//	net.Error with Timeout() true         Timeout and Retryable
+//	connection refused                    Unavailable and Retryable
+//	connection reset                      Unavailable and Retryable
+//	temporary DNS failure                 Unavailable and Retryable
+//	TLS handshake or certificate failure  Internal, not Retryable
+//
+// Any other error, including nil, is returned unchanged.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	cause := err
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		cause = ue.Err
+	}
+	var ne net.Error
+	var dns *net.DNSError
+	switch {
+	case errors.As(cause, &ne) && ne.Timeout():
+		return errs.New(errs.KindTimeout, errs.WithCode(CodeTimeout), errs.WithRetryable(),
+			errs.WithDetail("the request timed out"), errs.WithCause(cause))
+	case errors.Is(cause, syscall.ECONNREFUSED):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeConnRefused), errs.WithRetryable(),
+			errs.WithDetail("the connection was refused"), errs.WithCause(cause))
+	case errors.Is(cause, syscall.ECONNRESET):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeConnReset), errs.WithRetryable(),
+			errs.WithDetail("the connection was reset"), errs.WithCause(cause))
+	case errors.As(cause, &dns) && dns.IsTemporary:
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeDNSTemporary), errs.WithRetryable(),
+			errs.WithDetail("the host could not be resolved"), errs.WithCause(cause))
+	case isTLS(cause):
+		return errs.New(errs.KindInternal, errs.WithCode(CodeTLS), errs.WithCause(cause))
+	}
+	return err
+}
+
+// isTLS returns true if err was caused by a failed TLS handshake, either
+// locally or by an alert sent from the peer, which crypto/tls reports
+// as a *net.OpError with the op "remote error".
+func isTLS(err error) bool {
+	var op *net.OpError
+	if errors.As(err, &op) && op.Op == "remote error" {
+		return true
+	}
+	var (
+		header    tls.RecordHeaderError
+		verify    *tls.CertificateVerificationError
+		authority x509.UnknownAuthorityError
+		hostname  x509.HostnameError
+		invalid   x509.CertificateInvalidError
+	)
+	return errors.As(err, &header) || errors.As(err, &verify) ||
+		errors.As(err, &authority) || errors.As(err, &hostname) || errors.As(err, &invalid)
+}
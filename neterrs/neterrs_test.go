@@ -0,0 +1,170 @@
+package neterrs
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// listen starts a local listener that calls handle for each connection.
+func listen(t *testing.T, handle func(c net.Conn)) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go handle(c)
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err   func(t *testing.T) error
+		code  string
+		check func(err error) bool
+		retry bool
+	}{
+		"timeout should be a retryable timeout": {
+			err: func(t *testing.T) error {
+				done := make(chan struct{})
+				t.Cleanup(func() { close(done) })
+				addr := listen(t, func(c net.Conn) {
+					<-done
+					_ = c.Close()
+				})
+				client := http.Client{Timeout: 50 * time.Millisecond}
+				_, err := client.Get("http://user:secret@" + addr) //nolint:noctx // test request.
+				return err
+			},
+			code:  CodeTimeout,
+			check: lathos.IsTimeout,
+			retry: true,
+		},
+		"connection refused should be retryable and unavailable": {
+			err: func(t *testing.T) error {
+				l, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				addr := l.Addr().String()
+				_ = l.Close()
+				_, err = net.Dial("tcp", addr)
+				return err
+			},
+			code:  CodeConnRefused,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+		"connection reset should be retryable and unavailable": {
+			err: func(t *testing.T) error {
+				addr := listen(t, func(c net.Conn) {
+					_ = c.(*net.TCPConn).SetLinger(0)
+					_ = c.Close()
+				})
+				c, err := net.Dial("tcp", addr)
+				if err != nil {
+					// the reset can happen before the dial returns.
+					return err
+				}
+				defer c.Close()
+				_ = c.SetDeadline(time.Now().Add(time.Second))
+				_, err = c.Read(make([]byte, 1))
+				return err
+			},
+			code:  CodeConnReset,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+		"temporary dns failure should be retryable and unavailable": {
+			err: func(t *testing.T) error {
+				return &url.Error{Op: "Get", URL: "http://api.internal", Err: &net.OpError{
+					Op: "dial", Net: "tcp",
+					Err: &net.DNSError{Err: "server misbehaving", Name: "api.internal", IsTemporary: true},
+				}}
+			},
+			code:  CodeDNSTemporary,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+		"tls failure should be internal": {
+			err: func(t *testing.T) error {
+				srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+				srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+				srv.StartTLS()
+				t.Cleanup(srv.Close)
+				_, err := http.Get(srv.URL) //nolint:noctx // test request.
+				return err
+			},
+			code:  CodeTLS,
+			check: lathos.IsInternalError,
+		},
+		"tls alert should be internal": {
+			err: func(t *testing.T) error {
+				srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+				srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+				srv.TLS = &tls.Config{MinVersion: tls.VersionTLS13}
+				srv.StartTLS()
+				t.Cleanup(srv.Close)
+				// the server rejects the handshake with a protocol_version alert.
+				conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), &tls.Config{
+					InsecureSkipVerify: true, //nolint:gosec // the alert is the point of the test.
+					MaxVersion:         tls.VersionTLS12,
+				})
+				if err == nil {
+					_ = conn.Close()
+				}
+				return err
+			},
+			code:  CodeTLS,
+			check: lathos.IsInternalError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			cause := test.err(t)
+			is.True(cause != nil)
+			err := Translate(cause)
+			is.True(test.check(err))
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			is.Equal(lathos.IsRetryable(err), test.retry)
+			var ue *url.Error
+			is.True(!errors.As(err, &ue))                     // url errors are unwrapped
+			is.True(!strings.Contains(err.Error(), "secret")) // so credentials aren't included
+		})
+	}
+}
+
+func TestTranslate_PassThrough(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(Translate(nil))
+	other := errors.New("boom")
+	is.Equal(Translate(other), other)
+	notFound := &net.DNSError{Err: "no such host", Name: "missing.invalid", IsNotFound: true}
+	is.Equal(Translate(notFound), notFound)
+	mention := errors.New("config: tls: disabled")
+	is.Equal(Translate(mention), mention) // only typed tls errors are translated
+}
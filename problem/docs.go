@@ -0,0 +1,211 @@
+package problem
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Docs is an http.Handler serving documentation for each error code in a catalog,
+// in HTML or JSON depending on the Accept header:
+//
+//	GET {base}         an index of every error code
+//	GET {base}/{code}  the title, description, likely causes, remediation and an example response
+//
+// Setting Writer.TypeURI to Docs.TypeURI means the type of each problem written
+// resolves to its documentation:
+//
+//	docs, err := problem.NewDocs(catalog.Default(), "https://api.example.com/errors")
+//	mux.Handle("/errors/", docs)
+//	writer := problem.Writer{TypeURI: docs.TypeURI}
+type Docs struct {
+	cat  *catalog.Catalog
+	base string
+	path string
+}
+
+// NewDocs will create a new Docs handler for the catalog. The base is the URL the
+// handler is served from, it can be absolute or a path, and is used to build type URIs.
+func NewDocs(cat *catalog.Catalog, base string) (*Docs, error) {
+	u, err := url.Parse(strings.TrimSuffix(base, "/"))
+	if err != nil {
+		return nil, pkgerrs.Wrapf(err, "invalid docs base url %s", base)
+	}
+	return &Docs{
+		cat:  cat,
+		base: u.String(),
+		path: u.Path,
+	}, nil
+}
+
+// TypeURI will return the URI documenting code, if code is not
+// in the catalog an empty string is returned.
+func (d *Docs) TypeURI(code string) string {
+	if _, ok := d.cat.Lookup(code); !ok {
+		return ""
+	}
+	return d.base + "/" + url.PathEscape(code)
+}
+
+// ServeHTTP implements http.Handler.
+func (d *Docs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		WriteProblem(w, Problem{Type: DefaultType, Title: http.StatusText(http.StatusMethodNotAllowed), Status: http.StatusMethodNotAllowed})
+		return
+	}
+	rel := strings.Trim(strings.TrimPrefix(r.URL.Path, d.path), "/")
+	if rel == "" {
+		d.index(w, r)
+		return
+	}
+	code, err := url.PathUnescape(rel)
+	if err != nil {
+		code = rel
+	}
+	e, ok := d.cat.Lookup(code)
+	if !ok {
+		Writer{}.Write(w, r, errs.NewErrNotFoundf("", "error code %s is not documented", code))
+		return
+	}
+	d.entry(w, r, e)
+}
+
+// docIndex is an entry in the index.
+type docIndex struct {
+	Code      string            `json:"code"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Behaviour catalog.Behaviour `json:"behaviour"`
+	Type      string            `json:"type"`
+}
+
+// docEntry is the documentation for a single code.
+type docEntry struct {
+	docIndex
+	Description string   `json:"description,omitempty"`
+	Causes      []string `json:"causes,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+	Example     Problem  `json:"example"`
+}
+
+func (d *Docs) index(w http.ResponseWriter, r *http.Request) {
+	entries := d.cat.Entries()
+	idx := make([]docIndex, 0, len(entries))
+	for _, e := range entries {
+		idx = append(idx, d.docIndex(e))
+	}
+	if wantsJSON(r) {
+		writeJSON(w, struct {
+			Errors []docIndex `json:"errors"`
+		}{Errors: idx})
+		return
+	}
+	writeHTML(w, indexTemplate, idx)
+}
+
+func (d *Docs) entry(w http.ResponseWriter, r *http.Request, e catalog.Entry) {
+	doc := docEntry{
+		docIndex:    d.docIndex(e),
+		Description: e.Docs,
+		Causes:      e.Causes,
+		Remediation: e.Remediation,
+		Example:     Writer{TypeURI: d.TypeURI}.New(d.cat.New(e.Code, e.ExampleArgs()...)),
+	}
+	doc.Example.ID = exampleID
+	if wantsJSON(r) {
+		writeJSON(w, doc)
+		return
+	}
+	writeHTML(w, entryTemplate, doc)
+}
+
+func (d *Docs) docIndex(e catalog.Entry) docIndex {
+	return docIndex{
+		Code:      e.Code,
+		Title:     e.Title,
+		Status:    e.Status,
+		Behaviour: e.Behaviour,
+		Type:      d.TypeURI(e.Code),
+	}
+}
+
+// wantsJSON returns true if the client prefers JSON to HTML.
+func wantsJSON(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "json" {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "json") && !strings.Contains(accept, "text/html")
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeHTML(w http.ResponseWriter, t *template.Template, v interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = t.Execute(w, v)
+}
+
+var templateFuncs = template.FuncMap{ //nolint:gochecknoglobals // template helpers.
+	"json": func(v interface{}) string {
+		bb, _ := json.MarshalIndent(v, "", "  ")
+		return string(bb)
+	},
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Errors</title></head>
+<body>
+<h1>Errors</h1>
+<table>
+<thead><tr><th>Code</th><th>Title</th><th>Status</th><th>Behaviour</th></tr></thead>
+<tbody>
+{{- range . }}
+<tr><td><a href="{{ .Type }}">{{ .Code }}</a></td><td>{{ .Title }}</td><td>{{ .Status }}</td><td>{{ .Behaviour }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+var entryTemplate = template.Must(template.New("entry").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>{{ .Code }} {{ .Title }}</title></head>
+<body>
+<h1>{{ .Code }} {{ .Title }}</h1>
+<p>Status: {{ .Status }}, Behaviour: {{ .Behaviour }}</p>
+{{- with .Description }}
+<h2>Description</h2>
+<p>{{ . }}</p>
+{{- end }}
+{{- with .Causes }}
+<h2>Likely causes</h2>
+<ul>
+{{- range . }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- with .Remediation }}
+<h2>Remediation</h2>
+<p>{{ . }}</p>
+{{- end }}
+<h2>Example response</h2>
+<pre>{{ json .Example }}</pre>
+</body>
+</html>
+`))
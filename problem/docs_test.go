@@ -0,0 +1,131 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+func testDocs(t *testing.T) *Docs {
+	t.Helper()
+	cat := catalog.New()
+	cat.MustRegister(
+		catalog.Entry{
+			Code: "U404", Behaviour: catalog.NotFound, Title: "User not found", Detail: "user not found",
+			Docs: "The user does not exist.", Causes: []string{"The user was deleted."},
+			Remediation: "Check the user id.",
+		},
+		catalog.Entry{Code: "D001", Behaviour: catalog.Duplicate, Detail: "item %s already exists",
+			Params: []catalog.Param{{Name: "itemID", Type: "string"}}},
+	)
+	d, err := NewDocs(cat, "https://api.example.com/errors/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDocs_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	d := testDocs(t)
+	tests := map[string]struct {
+		method    string
+		path      string
+		accept    string
+		expStatus int
+		expType   string
+		expBody   []string
+	}{
+		"index should return json": {
+			path:      "/errors",
+			accept:    "application/json",
+			expStatus: http.StatusOK,
+			expType:   "application/json",
+			expBody:   []string{`"code": "D001"`, `"type": "https://api.example.com/errors/U404"`},
+		},
+		"index should return html by default": {
+			path:      "/errors/",
+			accept:    "text/html,application/xhtml+xml",
+			expStatus: http.StatusOK,
+			expType:   "text/html; charset=utf-8",
+			expBody:   []string{`<a href="https://api.example.com/errors/U404">U404</a>`},
+		},
+		"entry should return json": {
+			path:      "/errors/U404",
+			accept:    "application/json",
+			expStatus: http.StatusOK,
+			expType:   "application/json",
+			expBody: []string{
+				`"description": "The user does not exist."`, `"remediation": "Check the user id."`,
+				`"detail": "user not found"`, `"type": "https://api.example.com/errors/U404"`,
+			},
+		},
+		"entry with params should return example detail": {
+			path:      "/errors/D001",
+			accept:    "application/json",
+			expStatus: http.StatusOK,
+			expType:   "application/json",
+			expBody:   []string{`"detail": "item {itemID} already exists"`},
+		},
+		"entry should return html": {
+			path:      "/errors/U404",
+			expStatus: http.StatusOK,
+			expType:   "text/html; charset=utf-8",
+			expBody:   []string{"<h1>U404 User not found</h1>", "<li>The user was deleted.</li>", "Check the user id."},
+		},
+		"unknown code should return not found problem": {
+			path:      "/errors/X001",
+			expStatus: http.StatusNotFound,
+			expType:   ContentType,
+			expBody:   []string{"error code X001 is not documented"},
+		},
+		"post should not be allowed": {
+			method:    http.MethodPost,
+			path:      "/errors",
+			expStatus: http.StatusMethodNotAllowed,
+			expType:   ContentType,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, test.path, nil)
+			r.Header.Set("Accept", test.accept)
+			w := httptest.NewRecorder()
+			d.ServeHTTP(w, r)
+
+			is.Equal(test.expStatus, w.Code)
+			is.Equal(test.expType, w.Header().Get("Content-Type"))
+			for _, s := range test.expBody {
+				is.True(strings.Contains(w.Body.String(), s)) // body is missing expected content
+			}
+		})
+	}
+}
+
+func TestWriter_TypeURI(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	d := testDocs(t)
+	wr := Writer{TypeURI: d.TypeURI}
+
+	w := httptest.NewRecorder()
+	wr.Write(w, httptest.NewRequest(http.MethodGet, "/users/123", nil), errs.NewErrNotFound("U404", "user 123 not found"))
+	var p Problem
+	is.NoErr(json.NewDecoder(w.Body).Decode(&p))
+	is.Equal(p.Type, "https://api.example.com/errors/U404")
+	is.Equal(p.Instance, "/users/123")
+
+	is.Equal(wr.New(errs.NewErrNotFound("X001", "unknown")).Type, DefaultType)
+}
@@ -0,0 +1,168 @@
+package problem
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// SchemaName is the name of the Problem schema in the generated components.
+const SchemaName = "Problem"
+
+// Schema returns the OpenAPI 3 / JSON Schema for a Problem.
+func Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"type", "title", "status"},
+		// extension members can be added to a problem.
+		"additionalProperties": true,
+		"properties": map[string]interface{}{
+			"type": map[string]interface{}{
+				"type": "string", "format": "uri-reference", "default": DefaultType,
+				"description": "A URI identifying the problem type.",
+			},
+			"title": map[string]interface{}{
+				"type": "string", "description": "A short summary of the problem type.",
+			},
+			"status": map[string]interface{}{
+				"type": "integer", "format": "int32", "minimum": 100, "maximum": 599,
+				"description": "The HTTP status code.",
+			},
+			"detail": map[string]interface{}{
+				"type": "string", "description": "A human readable explanation of this occurrence of the problem.",
+			},
+			"instance": map[string]interface{}{
+				"type": "string", "format": "uri-reference",
+				"description": "A URI identifying this occurrence of the problem.",
+			},
+			"id": map[string]interface{}{
+				"type": "string", "description": "The error id, this can be used to find the error in logs.",
+			},
+			"code": map[string]interface{}{
+				"type": "string", "description": "The error code.",
+			},
+			"invalid-params": map[string]interface{}{
+				"type": "array", "description": "Each field that failed validation.",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"name", "reason"},
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type": "string", "description": "The path to the field, such as /address/city.",
+						},
+						"reason": map[string]interface{}{
+							"type": "string", "description": "Why the field is invalid.",
+						},
+						"rule": map[string]interface{}{
+							"type": "string", "description": "The validation rule that failed.",
+						},
+						"code": map[string]interface{}{
+							"type": "string", "description": "The error code of the violation.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Components returns OpenAPI 3 components containing the Problem schema and a response
+// for each HTTP status used by the catalog entries. Each response restricts the code to
+// the entries with that status and has an example body for each behaviour.
+//
+// The result can be marshalled to JSON or YAML and merged into a service's spec:
+//
+//	components:
+//	  schemas:
+//	    Problem: ...
+//	  responses:
+//	    NotFound: ...
+func Components(entries []catalog.Entry) map[string]interface{} {
+	byStatus := map[int][]catalog.Entry{}
+	for _, e := range entries {
+		byStatus[e.Status] = append(byStatus[e.Status], e)
+	}
+	responses := map[string]interface{}{}
+	for status, ee := range byStatus {
+		responses[ResponseName(status)] = response(status, ee)
+	}
+	return map[string]interface{}{
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				SchemaName: Schema(),
+			},
+			"responses": responses,
+		},
+	}
+}
+
+// ResponseName returns the name of the generated response for an HTTP status,
+// ie NotFound for 404. Non standard statuses are named by their number, ie Status499.
+func ResponseName(status int) string {
+	return strings.NewReplacer(" ", "", "-", "", "'", "").Replace(statusText(status))
+}
+
+// statusText returns the text for an HTTP status or, if it isn't a standard
+// status, a description with its number.
+func statusText(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	return fmt.Sprintf("Status %d", status)
+}
+
+func response(status int, entries []catalog.Entry) map[string]interface{} {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+	codes := make([]string, 0, len(entries))
+	examples := map[string]interface{}{}
+	for _, e := range entries {
+		codes = append(codes, e.Code)
+		if _, ok := examples[string(e.Behaviour)]; ok {
+			continue
+		}
+		examples[string(e.Behaviour)] = map[string]interface{}{
+			"summary": e.Title,
+			"value":   Example(e),
+		}
+	}
+	return map[string]interface{}{
+		"description": statusText(status),
+		"content": map[string]interface{}{
+			ContentType: map[string]interface{}{
+				"schema": map[string]interface{}{
+					"allOf": []interface{}{
+						map[string]interface{}{"$ref": "#/components/schemas/" + SchemaName},
+						map[string]interface{}{
+							"properties": map[string]interface{}{
+								"status": map[string]interface{}{"enum": []int{status}},
+								"code":   map[string]interface{}{"enum": codes},
+							},
+						},
+					},
+				},
+				"examples": examples,
+			},
+		},
+	}
+}
+
+// Example returns an example Problem for a catalog entry, it is created from an error
+// built by the catalog, with the entry's ExampleArgs, so matches what would be
+// written by a handler.
+func Example(e catalog.Entry) Problem {
+	c := catalog.New()
+	if err := c.Register(e); err != nil {
+		return Problem{Type: DefaultType, Title: e.Title, Status: e.Status, Code: e.Code}
+	}
+	p := New(c.New(e.Code, e.ExampleArgs()...))
+	p.ID = exampleID
+	return p
+}
+
+// exampleID is used as the id in example problems so they don't change each time they are generated.
+const exampleID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
@@ -0,0 +1,77 @@
+package problem
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos/catalog"
+)
+
+// TestSchema_MatchesProblem ensures the schema doesn't drift from what is written.
+func TestSchema_MatchesProblem(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	props := Schema()["properties"].(map[string]interface{})
+	typ := reflect.TypeOf(Problem{})
+	var fields int
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		fields++
+		_, ok := props[name]
+		is.True(ok) // schema is missing a Problem field
+	}
+	is.Equal(len(props), fields)
+	is.Equal(Schema()["additionalProperties"], true) // extensions must be allowed
+}
+
+func TestComponents(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	cat := catalog.New()
+	cat.MustRegister(
+		catalog.Entry{Code: "U404", Behaviour: catalog.NotFound, Title: "User not found", Detail: "user not found"},
+		catalog.Entry{Code: "D001", Behaviour: catalog.Duplicate},
+		catalog.Entry{Code: "C001", Behaviour: catalog.Conflict},
+		catalog.Entry{Code: "C002", Behaviour: catalog.Conflict},
+		catalog.Entry{Code: "X499", Behaviour: catalog.Canceled},
+		catalog.Entry{Code: "P402", Behaviour: catalog.CannotProcess, Detail: "order %s is over the limit of %d",
+			Params: []catalog.Param{{Name: "orderID", Type: "string"}, {Name: "limit", Type: "int"}}},
+	)
+	components := Components(cat.Entries())["components"].(map[string]interface{})
+	_, ok := components["schemas"].(map[string]interface{})[SchemaName]
+	is.True(ok)
+
+	responses := components["responses"].(map[string]interface{})
+	is.Equal(len(responses), 4)
+	_, ok = responses[""]
+	is.True(!ok)
+	canceled := responses["Status499"].(map[string]interface{})
+	is.Equal(canceled["description"], "Status 499")
+
+	content := responses["Conflict"].(map[string]interface{})["content"].(map[string]interface{})[ContentType].(map[string]interface{})
+	schema := content["schema"].(map[string]interface{})["allOf"].([]interface{})[1].(map[string]interface{})
+	codes := schema["properties"].(map[string]interface{})["code"].(map[string]interface{})["enum"]
+	is.Equal(codes, []string{"C001", "C002", "D001"})
+
+	examples := content["examples"].(map[string]interface{})
+	is.Equal(len(examples), 2) // one per behaviour
+	dup := examples["duplicate"].(map[string]interface{})["value"].(Problem)
+	is.Equal(dup.Code, "D001")
+	is.Equal(dup.Status, 409)
+
+	notFound := responses["NotFound"].(map[string]interface{})["content"].(map[string]interface{})[ContentType].(map[string]interface{})
+	example := notFound["examples"].(map[string]interface{})["not_found"].(map[string]interface{})["value"].(Problem)
+	is.Equal(example.Title, "User not found")
+	is.Equal(example.Detail, "user not found")
+
+	unprocessable := responses["UnprocessableEntity"].(map[string]interface{})["content"].(map[string]interface{})[ContentType].(map[string]interface{})
+	example = unprocessable["examples"].(map[string]interface{})["cannot_process"].(map[string]interface{})["value"].(Problem)
+	is.Equal(example.Code, "P402")
+	is.Equal(example.Detail, "order {orderID} is over the limit of 1") // params are filled with sample values
+}
@@ -0,0 +1,235 @@
+// Package problem converts lathos errors to RFC 7807 problem details
+// responses so they can be returned from an http server.
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		if err := do(r); err != nil {
+//			problem.Write(w, err)
+//			return
+//		}
+//	}
+//
+// Client errors are written with their title, detail and code. Internal errors
+// only expose their id and code so no program information is leaked.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/i18n"
+)
+
+// ContentType is the media type problem details are written with.
+const ContentType = "application/problem+json"
+
+// DefaultType is used as the type of a problem when no other is known.
+const DefaultType = "about:blank"
+
+// Problem is a RFC 7807 problem details response with the lathos
+// id and code added as extension members.
+type Problem struct {
+	// Type is a URI identifying the problem type.
+	Type string `json:"type" yaml:"type"`
+	// Title is a short summary of the problem type.
+	Title string `json:"title" yaml:"title"`
+	// Status is the HTTP status code.
+	Status int `json:"status" yaml:"status"`
+	// Detail is a human readable explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	// Instance is a URI identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty" yaml:"instance,omitempty"`
+	// ID is the lathos error id, this can be used to find the error in logs.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Code is the lathos error code.
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+	// InvalidParams lists each field that failed validation.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty" yaml:"invalid-params,omitempty"`
+	// Extensions are additional members written alongside the standard members,
+	// they can't replace a standard member.
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
+}
+
+// InvalidParam is a field that failed validation, created from a lathos.Violation.
+type InvalidParam struct {
+	// Name is the path to the field, such as /address/city.
+	Name string `json:"name" yaml:"name"`
+	// Reason is a human readable explanation of why the field is invalid.
+	Reason string `json:"reason" yaml:"reason"`
+	// Rule is the validation rule that failed.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Code is the error code of the violation.
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+// MarshalJSON writes the problem with its extension members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	b, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return b, err
+	}
+	ext := make(map[string]interface{}, len(p.Extensions))
+	for k, v := range p.Extensions {
+		if _, ok := members[k]; !ok {
+			ext[k] = v
+		}
+	}
+	if len(ext) == 0 {
+		return b, nil
+	}
+	eb, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	b[len(b)-1] = ','
+	return append(b, eb[1:]...), nil
+}
+
+// members is a read only lookup of the standard problem member names.
+var members = map[string]struct{}{ //nolint:gochecknoglobals // read only lookup table.
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {}, "id": {}, "code": {}, "invalid-params": {},
+}
+
+// extender is implemented by errors with extension members, such as those created by errs.New.
+type extender interface {
+	Extensions() map[string]interface{}
+}
+
+// statusError is implemented by errors that know their HTTP status, such as catalog.Error.
+type statusError interface {
+	Status() int
+}
+
+// Writer converts errors to problems and writes them, the zero value is ready to use.
+type Writer struct {
+	// TypeURI if set returns the type URI for an error code, an empty string
+	// means the code is unknown and DefaultType is used. This can be set to
+	// Docs.TypeURI so each problem type resolves to its documentation.
+	TypeURI func(code string) string
+	// Localizer if set translates the title and detail of problems to the
+	// languages requested in the Accept-Language header, an i18n.Bundle can be used.
+	Localizer Localizer
+	// Translators if set are used to normalize errors rather than those
+	// registered with lathos.RegisterTranslator.
+	Translators []lathos.Translator
+}
+
+// Localizer translates the title and detail of an error to the first of langs
+// it has a message for, returning the language used. Empty fields in the
+// message mean the error's own title or detail is used.
+type Localizer interface {
+	Localize(err error, langs ...string) (m i18n.Message, lang string, ok bool)
+}
+
+// New will convert err to a Problem. The status is taken from the error if it has
+// a Status() int method, otherwise it is derived from its lathos behaviours, client
+// errors without a known behaviour are a 400 and any other error a 500.
+// Errors without a behaviour are first passed to lathos.Normalize so any
+// registered translators, or the Writer's Translators if set, are used.
+func (wr Writer) New(err error) Problem {
+	p, _ := wr.normalize(err)
+	return p
+}
+
+// normalize passes err to lathos.Normalize and converts the result to a Problem,
+// the normalized error is also returned so Write can read its other behaviours.
+func (wr Writer) normalize(err error) (Problem, error) {
+	if wr.Translators != nil {
+		err = lathos.NormalizeWith(err, wr.Translators...)
+	} else {
+		err = lathos.Normalize(err)
+	}
+	b := catalog.BehaviourOf(err)
+	if b == catalog.Internal && lathos.IsClientError(err) {
+		// a client error without a known behaviour is still the fault of the caller.
+		b = catalog.BadRequest
+	}
+	p := Problem{
+		Type:   DefaultType,
+		Status: b.Status(),
+	}
+	var se statusError
+	if errors.As(err, &se) {
+		p.Status = se.Status()
+	}
+	var ce lathos.ClientError
+	var ie lathos.InternalError
+	switch {
+	case errors.As(err, &ce):
+		p.Title, p.Detail, p.ID, p.Code = ce.Title(), ce.Detail(), ce.ID(), ce.Code()
+		for _, v := range lathos.ViolationsOf(err) {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: v.Field, Reason: v.Message, Rule: v.Rule, Code: v.Code})
+		}
+	case errors.As(err, &ie):
+		p.Title, p.ID, p.Code = http.StatusText(p.Status), ie.ID(), ie.Code()
+	default:
+		p.Title = http.StatusText(p.Status)
+	}
+	var ex extender
+	if errors.As(err, &ex) && len(ex.Extensions()) > 0 {
+		p.Extensions = ex.Extensions()
+	}
+	if wr.TypeURI != nil && p.Code != "" {
+		if uri := wr.TypeURI(p.Code); uri != "" {
+			p.Type = uri
+		}
+	}
+	return p, err
+}
+
+// Write will convert err to a Problem and write it to w. The request is
+// optional, if supplied its path is used as the problem instance.
+// If the error implements lathos.RetryAfter the Retry-After header is set.
+func (wr Writer) Write(w http.ResponseWriter, r *http.Request, err error) {
+	p, err := wr.normalize(err)
+	if d, ok := lathos.RetryAfterOf(err); ok && d > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+		wr.localize(w, r, err, &p)
+	}
+	WriteProblem(w, p)
+}
+
+// localize translates p to the language requested by r, internal
+// errors only have their title translated so nothing is leaked.
+func (wr Writer) localize(w http.ResponseWriter, r *http.Request, err error, p *Problem) {
+	if wr.Localizer == nil {
+		return
+	}
+	w.Header().Add("Vary", "Accept-Language")
+	m, lang, ok := wr.Localizer.Localize(err, i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))...)
+	if !ok {
+		return
+	}
+	if m.Title != "" {
+		p.Title = m.Title
+	}
+	if m.Detail != "" && lathos.IsClientError(err) {
+		p.Detail = m.Detail
+	}
+	w.Header().Set("Content-Language", lang)
+}
+
+// New will convert err to a Problem using the default Writer.
+func New(err error) Problem {
+	return Writer{}.New(err)
+}
+
+// Write will convert err to a Problem and write it to w using the default Writer.
+func Write(w http.ResponseWriter, err error) {
+	Writer{}.Write(w, nil, err)
+}
+
+// WriteProblem will write p to w as JSON with the problem content type.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,215 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/catalog"
+	"github.com/theflyingcodr/lathos/errs"
+	"github.com/theflyingcodr/lathos/i18n"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	cat := catalog.New()
+	cat.MustRegister(catalog.Entry{Code: "P402", Behaviour: catalog.CannotProcess, Title: "Payment required", Status: 402})
+	notFound := errs.NewErrNotFound("U404", "user 123 not found")
+	internal := errs.NewErrInternal(errors.New("secret connection string"), "I001")
+	wrapped := errs.NewErrInternal(errs.NewErrNotFound("E404", "secret"), "I001")
+	tests := map[string]struct {
+		err error
+		exp Problem
+	}{
+		"client error should expose title and detail": {
+			err: pkgerrs.Wrap(notFound, "get user"),
+			exp: Problem{
+				Type: DefaultType, Title: "Not found", Status: http.StatusNotFound,
+				Detail: "user 123 not found", ID: notFound.ID(), Code: "U404",
+			},
+		},
+		"duplicate error should be a conflict": {
+			err: errs.NewErrDuplicate("D001", "exists"),
+			exp: Problem{Type: DefaultType, Title: "Item already exists", Status: http.StatusConflict, Detail: "exists", Code: "D001"},
+		},
+		"catalog error should use its status": {
+			err: cat.New("P402"),
+			exp: Problem{Type: DefaultType, Title: "Payment required", Status: http.StatusPaymentRequired, Code: "P402"},
+		},
+		"internal error should not expose its message": {
+			err: internal,
+			exp: Problem{
+				Type: DefaultType, Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				ID: internal.ID(), Code: "I001",
+			},
+		},
+		"internal error wrapping a client error should not expose it": {
+			err: wrapped,
+			exp: Problem{
+				Type: DefaultType, Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				ID: wrapped.ID(), Code: "I001",
+			},
+		},
+		"client error without a behaviour should be a bad request": {
+			err: testClientErr{},
+			exp: Problem{Type: DefaultType, Title: "Payment failed", Status: http.StatusBadRequest, Detail: "card declined", Code: "P001"},
+		},
+		"unknown error should be internal": {
+			err: errors.New("boom"),
+			exp: Problem{Type: DefaultType, Title: "Internal Server Error", Status: http.StatusInternalServerError},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			p := New(test.err)
+			if test.exp.ID == "" {
+				p.ID = ""
+			}
+			is.Equal(test.exp, p)
+		})
+	}
+}
+
+// testClientErr is a client error that doesn't implement any other behaviour.
+type testClientErr struct{}
+
+func (testClientErr) ID() string     { return "" }
+func (testClientErr) Code() string   { return "P001" }
+func (testClientErr) Title() string  { return "Payment failed" }
+func (testClientErr) Detail() string { return "card declined" }
+func (testClientErr) Error() string  { return "Payment failed: card declined" }
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	w := httptest.NewRecorder()
+	Write(w, errs.NewErrBadRequest("B001", "name is required"))
+
+	is.Equal(w.Code, http.StatusBadRequest)
+	is.Equal(w.Header().Get("Content-Type"), ContentType)
+	var p Problem
+	is.NoErr(json.NewDecoder(w.Body).Decode(&p))
+	is.Equal(p.Title, "Bad Request")
+	is.Equal(p.Detail, "name is required")
+	is.Equal(p.Code, "B001")
+}
+
+// testForeignErr is a third party error translated by the Writer's translators.
+type testForeignErr struct{}
+
+func (testForeignErr) Error() string {
+	return "foreign: no rows"
+}
+
+func TestWrite_Normalize(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	wr := Writer{Translators: []lathos.Translator{func(err error) error {
+		if !errors.As(err, &testForeignErr{}) {
+			return err
+		}
+		return errs.New(errs.KindNotFound, errs.WithCode("F404"), errs.WithCause(err))
+	}}}
+	w := httptest.NewRecorder()
+	wr.Write(w, nil, pkgerrs.Wrap(testForeignErr{}, "get user"))
+
+	is.Equal(w.Code, http.StatusNotFound)
+	var p Problem
+	is.NoErr(json.NewDecoder(w.Body).Decode(&p))
+	is.Equal(p.Code, "F404")
+}
+
+func TestWrite_InvalidParams(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	w := httptest.NewRecorder()
+	Write(w, errs.NewCollector("V001").
+		Add("/name", "required", "name is required").
+		AddViolation(lathos.Violation{Field: "/age", Rule: "min", Message: "too young", Code: "V002"}).
+		Err())
+
+	is.Equal(w.Code, http.StatusBadRequest)
+	var p Problem
+	is.NoErr(json.NewDecoder(w.Body).Decode(&p))
+	is.Equal(p.Code, "V001")
+	is.Equal(p.InvalidParams, []InvalidParam{
+		{Name: "/name", Reason: "name is required", Rule: "required"},
+		{Name: "/age", Reason: "too young", Rule: "min", Code: "V002"},
+	})
+}
+
+func TestWrite_Extensions(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	w := httptest.NewRecorder()
+	Write(w, errs.New(errs.KindTooManyRequests,
+		errs.WithCode("R429"),
+		errs.WithRetryAfter(1500*time.Millisecond),
+		errs.WithExtensions(map[string]interface{}{"limit": 10, "code": "ignored"})))
+
+	is.Equal(w.Code, http.StatusTooManyRequests)
+	is.Equal(w.Header().Get("Retry-After"), "2")
+	var m map[string]interface{}
+	is.NoErr(json.NewDecoder(w.Body).Decode(&m))
+	is.Equal(m["limit"], float64(10))
+	is.Equal(m["code"], "R429")
+}
+
+func TestWriter_Localizer(t *testing.T) {
+	t.Parallel()
+	b := i18n.NewBundle("en")
+	b.Add("de", "not_found", i18n.Message{Title: "Nicht gefunden", Detail: "Nicht gefunden"})
+	b.Add("de", "internal", i18n.Message{Title: "Interner Fehler", Detail: "geheim"})
+	wr := Writer{Localizer: b}
+	tests := map[string]struct {
+		err       error
+		lang      string
+		expTitle  string
+		expDetail string
+		expLang   string
+	}{
+		"client error should be translated": {
+			err:       errs.NewErrNotFound("U404", "user not found"),
+			lang:      "de-DE,de;q=0.9,en;q=0.8",
+			expTitle:  "Nicht gefunden",
+			expDetail: "Nicht gefunden",
+			expLang:   "de",
+		},
+		"internal error should only translate title": {
+			err:      errors.New("boom"),
+			lang:     "de",
+			expTitle: "Interner Fehler",
+			expLang:  "de",
+		},
+		"unknown language should not be translated": {
+			err:       errs.NewErrNotFound("U404", "user not found"),
+			lang:      "fr",
+			expTitle:  "Not found",
+			expDetail: "user not found",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Language", test.lang)
+			w := httptest.NewRecorder()
+			wr.Write(w, r, test.err)
+
+			var p Problem
+			is.NoErr(json.NewDecoder(w.Body).Decode(&p))
+			is.Equal(test.expTitle, p.Title)
+			is.Equal(test.expDetail, p.Detail)
+			is.Equal(test.expLang, w.Header().Get("Content-Language"))
+			is.Equal("Accept-Language", w.Header().Get("Vary"))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package sqlerrs translates database/sql and driver errors into lathos
+// errors so repositories classify them the same way:
+//
+//	row := db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = $1", id)
+//	if err := row.Scan(&name); err != nil {
+//		return sqlerrs.Translate(err)
+//	}
+//
+// Driver errors exposing a SQLState() string method, such as those from pgx
+// and lib/pq, are classified by their SQLSTATE. The original error is kept
+// as the cause so errors.Is(err, sql.ErrNoRows) still works, but its message
+// is never used as the detail so queries and values aren't exposed to clients.
+package sqlerrs
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// Codes of the errors returned by Translate.
+const (
+	CodeNoRows               = "SQL_NO_ROWS"
+	CodeBadConn              = "SQL_BAD_CONN"
+	CodeConnDone             = "SQL_CONN_DONE"
+	CodeTxDone               = "SQL_TX_DONE"
+	CodeUniqueViolation      = "SQL_UNIQUE_VIOLATION"
+	CodeForeignKeyViolation  = "SQL_FOREIGN_KEY_VIOLATION"
+	CodeSerializationFailure = "SQL_SERIALIZATION_FAILURE"
+	CodeDeadlock             = "SQL_DEADLOCK"
+	CodeQueryCanceled        = "SQL_QUERY_CANCELED"
+	CodeConnectionException  = "SQL_CONNECTION_EXCEPTION"
+)
+
+// SQLSTATE codes classified by Translate.
+const (
+	StateUniqueViolation      = "23505"
+	StateForeignKeyViolation  = "23503"
+	StateSerializationFailure = "40001"
+	StateDeadlockDetected     = "40P01"
+	StateQueryCanceled        = "57014"
+	// ClassConnectionException is the class, the first two characters, of
+	// SQLSTATE codes for connection failures.
+	ClassConnectionException = "08"
+)
+
+// sqlStater is implemented by driver errors that expose their SQLSTATE.
+type sqlStater interface {
+	SQLState() string
+}
+
+// Translate maps err to a lathos error:
+//
+//	sql.ErrNoRows                  NotFound
+//	driver.ErrBadConn              Unavailable and Retryable
+//	sql.ErrConnDone                Unavailable and Retryable
+//	sql.ErrTxDone                  Internal
+//	23505 unique_violation         Duplicate
+//	23503 foreign_key_violation    Conflict
+//	40001 serialization_failure    Retryable
+//	40P01 deadlock_detected        Retryable
+//	57014 query_canceled           Timeout
+//	08xxx connection exception     Unavailable and Retryable
+//
+// Any other error, including nil, is returned unchanged.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return errs.New(errs.KindNotFound, errs.WithCode(CodeNoRows),
+			errs.WithDetail("record not found"), errs.WithCause(err))
+	case errors.Is(err, driver.ErrBadConn):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeBadConn), errs.WithRetryable(),
+			errs.WithDetail("database connection failed"), errs.WithCause(err))
+	case errors.Is(err, sql.ErrConnDone):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeConnDone), errs.WithRetryable(),
+			errs.WithDetail("database connection closed"), errs.WithCause(err))
+	case errors.Is(err, sql.ErrTxDone):
+		return errs.New(errs.KindInternal, errs.WithCode(CodeTxDone), errs.WithCause(err))
+	}
+	var s sqlStater
+	if !errors.As(err, &s) {
+		return err
+	}
+	state := s.SQLState()
+	switch {
+	case state == StateUniqueViolation:
+		return errs.New(errs.KindDuplicate, errs.WithCode(CodeUniqueViolation),
+			errs.WithDetail("record already exists"), errs.WithCause(err))
+	case state == StateForeignKeyViolation:
+		return errs.New(errs.KindConflict, errs.WithCode(CodeForeignKeyViolation),
+			errs.WithDetail("record is referenced by, or references, another record"), errs.WithCause(err))
+	case state == StateSerializationFailure:
+		return errs.New(errs.KindRetryable, errs.WithCode(CodeSerializationFailure),
+			errs.WithDetail("transaction could not be serialised"), errs.WithCause(err))
+	case state == StateDeadlockDetected:
+		return errs.New(errs.KindRetryable, errs.WithCode(CodeDeadlock),
+			errs.WithDetail("transaction deadlocked"), errs.WithCause(err))
+	case state == StateQueryCanceled:
+		return errs.New(errs.KindTimeout, errs.WithCode(CodeQueryCanceled),
+			errs.WithDetail("database query timed out"), errs.WithCause(err))
+	case strings.HasPrefix(state, ClassConnectionException):
+		return errs.New(errs.KindUnavailable, errs.WithCode(CodeConnectionException), errs.WithRetryable(),
+			errs.WithDetail("database connection failed"), errs.WithCause(err))
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package sqlerrs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+// testStateErr is a driver error exposing a SQLSTATE like pgx and lib/pq.
+type testStateErr struct {
+	state string
+}
+
+func (e testStateErr) Error() string {
+	return "pq: duplicate key value violates unique constraint \"users_email_key\" (" + e.state + ")"
+}
+
+func (e testStateErr) SQLState() string {
+	return e.state
+}
+
+// testDriver is a fake database/sql driver, queries starting with "state"
+// fail with the SQLSTATE that follows, "badconn" fails with driver.ErrBadConn
+// and anything else returns no rows.
+type testDriver struct{}
+
+func (testDriver) Open(string) (driver.Conn, error) {
+	return testConn{}, nil
+}
+
+type testConn struct{}
+
+func (testConn) Prepare(query string) (driver.Stmt, error) {
+	return testStmt{query: query}, nil
+}
+
+func (testConn) Close() error {
+	return nil
+}
+
+func (testConn) Begin() (driver.Tx, error) {
+	return testTx{}, nil
+}
+
+type testTx struct{}
+
+func (testTx) Commit() error {
+	return nil
+}
+
+func (testTx) Rollback() error {
+	return nil
+}
+
+type testStmt struct {
+	query string
+}
+
+func (testStmt) Close() error {
+	return nil
+}
+
+func (testStmt) NumInput() int {
+	return -1
+}
+
+func (s testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, s.err()
+}
+
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	if err := s.err(); err != nil {
+		return nil, err
+	}
+	return testRows{}, nil
+}
+
+func (s testStmt) err() error {
+	switch {
+	case s.query == "badconn":
+		return driver.ErrBadConn
+	case strings.HasPrefix(s.query, "state "):
+		return testStateErr{state: strings.TrimPrefix(s.query, "state ")}
+	}
+	return nil
+}
+
+type testRows struct{}
+
+func (testRows) Columns() []string {
+	return []string{"name"}
+}
+
+func (testRows) Close() error {
+	return nil
+}
+
+func (testRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func init() {
+	sql.Register("sqlerrs_test", testDriver{})
+}
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	db, err := sql.Open("sqlerrs_test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+	query := func(q string) func() error {
+		return func() error {
+			var name string
+			return db.QueryRowContext(ctx, q).Scan(&name)
+		}
+	}
+
+	tests := map[string]struct {
+		run   func() error
+		code  string
+		check func(err error) bool
+		retry bool
+	}{
+		"no rows should be not found": {
+			run:   query("SELECT name FROM users"),
+			code:  CodeNoRows,
+			check: lathos.IsNotFound,
+		},
+		"bad connection should be unavailable": {
+			run:   query("badconn"),
+			code:  CodeBadConn,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+		"closed connection should be unavailable": {
+			run: func() error {
+				conn, err := db.Conn(ctx)
+				if err != nil {
+					return err
+				}
+				_ = conn.Close()
+				return conn.PingContext(ctx)
+			},
+			code:  CodeConnDone,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+		"finished transaction should be internal": {
+			run: func() error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				_ = tx.Commit()
+				return tx.Commit()
+			},
+			code:  CodeTxDone,
+			check: lathos.IsInternalError,
+		},
+		"unique violation should be a duplicate": {
+			run:   query("state 23505"),
+			code:  CodeUniqueViolation,
+			check: lathos.IsDuplicate,
+		},
+		"foreign key violation should be a conflict": {
+			run:   query("state 23503"),
+			code:  CodeForeignKeyViolation,
+			check: lathos.IsConflict,
+		},
+		"serialization failure should be retryable": {
+			run:   query("state 40001"),
+			code:  CodeSerializationFailure,
+			check: lathos.IsInternalError,
+			retry: true,
+		},
+		"deadlock should be retryable": {
+			run:   query("state 40P01"),
+			code:  CodeDeadlock,
+			check: lathos.IsInternalError,
+			retry: true,
+		},
+		"query canceled should be a timeout": {
+			run:   query("state 57014"),
+			code:  CodeQueryCanceled,
+			check: lathos.IsTimeout,
+		},
+		"connection exception class should be unavailable": {
+			run:   query("state 08006"),
+			code:  CodeConnectionException,
+			check: lathos.IsUnavailable,
+			retry: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			cause := test.run()
+			is.True(cause != nil)
+			err := Translate(cause)
+			is.True(test.check(err))
+			is.True(errors.Is(err, lathos.CodeMatcher(test.code)))
+			is.Equal(lathos.IsRetryable(err), test.retry)
+			is.True(errors.Is(err, cause)) // original is kept as the cause
+			var c lathos.ClientError
+			if errors.As(err, &c) {
+				is.True(!strings.Contains(c.Detail(), "users_email_key")) // driver messages aren't exposed
+			}
+		})
+	}
+}
+
+func TestTranslate_PassThrough(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	is.NoErr(Translate(nil))
+	other := errors.New("boom")
+	is.Equal(Translate(other), other)
+	state := testStateErr{state: "42601"}
+	is.Equal(Translate(state), state)
+}
@@ -0,0 +1,93 @@
+package lathos
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Translator converts a foreign error, such as one from database/sql, into an
+// error with a lathos behaviour. Errors it doesn't recognise should be returned unchanged.
+type Translator func(err error) error
+
+// translators is the ordered chain used by Normalize.
+var translators struct { //nolint:gochecknoglobals // registry shared by Normalize.
+	sync.RWMutex
+	chain []Translator
+}
+
+// RegisterTranslator adds fn to the end of the chain of translators
+// run by Normalize, translators are run in the order they are registered.
+// This is usually called once when a service starts.
+func RegisterTranslator(fn Translator) {
+	translators.Lock()
+	defer translators.Unlock()
+	translators.chain = append(translators.chain, fn)
+}
+
+// Normalize runs the registered translators over err, if it has no lathos behaviour,
+// and returns the first result that does. This gives one place where third party errors
+// are classified, such as an http error handler before it maps the error to a response.
+//
+// The original error is kept as a cause of the result so it can still be found
+// with errors.Is and errors.As. If err already has a behaviour, or no translator
+// recognises it, err is returned unchanged.
+func Normalize(err error) error {
+	translators.RLock()
+	chain := translators.chain
+	translators.RUnlock()
+	return NormalizeWith(err, chain...)
+}
+
+// NormalizeWith is the same as Normalize but uses the provided translators
+// rather than those registered.
+func NormalizeWith(err error, chain ...Translator) error {
+	if err == nil || classified(err) {
+		return err
+	}
+	for _, fn := range chain {
+		t := fn(err)
+		if t == nil || !classified(t) {
+			continue
+		}
+		if errors.Is(t, err) {
+			return t
+		}
+		return normalizedErr{err: t, cause: err}
+	}
+	return err
+}
+
+// classified returns true if err, or an error it wraps, implements a
+// behaviour used to classify errors. Timeout isn't checked as many standard
+// library errors implement it, they still need translating.
+func classified(err error) bool {
+	for _, is := range []func(error) bool{
+		IsClientError, IsInternalError, IsNotFound, IsDuplicate, IsNotAuthorised,
+		IsNotAuthenticated, IsBadRequest, IsCannotProcess, IsUnavailable, IsRetryable,
+		IsTooManyRequests, IsConflict, IsCanceled,
+	} {
+		if is(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizedErr is returned by Normalize when a translator didn't keep
+// the original error as a cause.
+type normalizedErr struct {
+	err   error
+	cause error
+}
+
+// Error returns the message of the translated error.
+func (n normalizedErr) Error() string {
+	return n.err.Error()
+}
+
+// Unwrap returns the translated error followed by the original error,
+// so behaviours are found on the translated error first.
+func (n normalizedErr) Unwrap() []error {
+	return []error{n.err, n.cause}
+}
@@ -0,0 +1,41 @@
+// Package translate holds the default chain of translators used by
+// lathos.Normalize, it can't live in the lathos package as the translators
+// depend on the errs types.
+//
+//	func main() {
+//		translate.RegisterDefaults()
+//		lathos.RegisterTranslator(myDriverTranslator)
+//		...
+//	}
+package translate
+
+import (
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/ctxerrs"
+	"github.com/theflyingcodr/lathos/neterrs"
+	"github.com/theflyingcodr/lathos/sqlerrs"
+)
+
+// Defaults returns the built-in translators in the order they should run.
+// Context errors are first as context.DeadlineExceeded is also a net.Error,
+// followed by database/sql and network errors.
+//
+// JSON decode errors aren't included as they are only the fault of a client when
+// decoding a request body, use lathos.FromJSONDecode where the body is decoded.
+// Filesystem errors aren't included either as a missing file on the server
+// shouldn't be a 404, register fserrs.Translate if files are named by clients.
+func Defaults() []lathos.Translator {
+	return []lathos.Translator{
+		ctxerrs.Translate,
+		sqlerrs.Translate,
+		neterrs.Translate,
+	}
+}
+
+// RegisterDefaults registers the Defaults with lathos.RegisterTranslator,
+// it should only be called once.
+func RegisterDefaults() {
+	for _, fn := range Defaults() {
+		lathos.RegisterTranslator(fn)
+	}
+}
@@ -0,0 +1,67 @@
+package translate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+	var syntaxErr error = &json.SyntaxError{Offset: 3}
+	if err := json.Unmarshal([]byte(`{"a"}`), &struct{}{}); err != nil {
+		syntaxErr = err
+	}
+	tests := map[string]struct {
+		err   error
+		check func(err error) bool
+	}{
+		"context deadline should be a timeout": {
+			err:   fmt.Errorf("query: %w", context.DeadlineExceeded),
+			check: func(err error) bool { return lathos.IsTimeout(err) && lathos.IsClientError(err) },
+		},
+		"context canceled should be canceled": {
+			err:   fmt.Errorf("query: %w", context.Canceled),
+			check: lathos.IsCanceled,
+		},
+		"json syntax error should not be a client error": {
+			err:   syntaxErr,
+			check: func(err error) bool { return !lathos.IsClientError(err) },
+		},
+		"no rows should be not found": {
+			err:   fmt.Errorf("get user: %w", sql.ErrNoRows),
+			check: lathos.IsNotFound,
+		},
+		"missing file should not be a client error": {
+			err:   &fs.PathError{Op: "open", Path: "/data/key", Err: fs.ErrNotExist},
+			check: func(err error) bool { return !lathos.IsClientError(err) },
+		},
+		"connection refused should be unavailable": {
+			err:   &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			check: func(err error) bool { return lathos.IsUnavailable(err) && lathos.IsRetryable(err) },
+		},
+		"EOF should not be an empty body": {
+			err:   fmt.Errorf("read: %w", io.EOF),
+			check: func(err error) bool { return !lathos.IsClientError(err) },
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := lathos.NormalizeWith(test.err, Defaults()...)
+			is.True(test.check(err))
+			is.True(errors.Is(err, test.err)) // original is kept as the cause
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package lathos
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// testForeignErr is a third party error without any behaviours.
+type testForeignErr struct {
+	msg string
+}
+
+func (t testForeignErr) Error() string {
+	return t.msg
+}
+
+// testNotFoundCause is a NotFound error that keeps its cause.
+type testNotFoundCause struct {
+	testNotFound
+	cause error
+}
+
+func (t testNotFoundCause) Unwrap() error {
+	return t.cause
+}
+
+func translateForeign(err error) error {
+	var f testForeignErr
+	if !errors.As(err, &f) {
+		return err
+	}
+	if f.msg == "lossy" {
+		return &testUnavailable{}
+	}
+	return testNotFoundCause{testNotFound: testNotFound{testClientErr{errors.New("not found")}}, cause: err}
+}
+
+func TestNormalizeWith(t *testing.T) {
+	t.Parallel()
+	ignore := func(err error) error { return err }
+	tests := map[string]struct {
+		err   error
+		chain []Translator
+		check func(err error) bool
+	}{
+		"nil should stay nil": {
+			chain: []Translator{translateForeign},
+			check: func(err error) bool { return err == nil },
+		},
+		"foreign error should be translated": {
+			err:   fmt.Errorf("get user: %w", testForeignErr{msg: "no rows"}),
+			chain: []Translator{ignore, translateForeign},
+			check: IsNotFound,
+		},
+		"translator dropping the cause should be wrapped": {
+			err:   testForeignErr{msg: "lossy"},
+			chain: []Translator{translateForeign},
+			check: IsUnavailable,
+		},
+		"first translator with a behaviour should win": {
+			err: testForeignErr{msg: "no rows"},
+			chain: []Translator{translateForeign, func(err error) error {
+				return &testDuplicate{}
+			}},
+			check: func(err error) bool { return IsNotFound(err) && !IsDuplicate(err) },
+		},
+		"error with a behaviour should be unchanged": {
+			err: &testDuplicate{},
+			chain: []Translator{func(err error) error {
+				return &testUnavailable{}
+			}},
+			check: func(err error) bool { return IsDuplicate(err) && !IsUnavailable(err) },
+		},
+		"unrecognised error should be unchanged": {
+			err:   errors.New("boom"),
+			chain: []Translator{translateForeign},
+			check: func(err error) bool { return err.Error() == "boom" && !IsClientError(err) },
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := NormalizeWith(test.err, test.chain...)
+			is.True(test.check(err))
+			if test.err != nil {
+				is.True(errors.Is(err, test.err)) // original is kept as the cause
+			}
+		})
+	}
+}
+
+// TestNormalize isn't parallel as it modifies the registered translators.
+func TestNormalize(t *testing.T) {
+	is := is.New(t)
+	translators.Lock()
+	chain := translators.chain
+	translators.chain = nil
+	translators.Unlock()
+	t.Cleanup(func() {
+		translators.Lock()
+		translators.chain = chain
+		translators.Unlock()
+	})
+	RegisterTranslator(translateForeign)
+	err := Normalize(testForeignErr{msg: "registered"})
+	is.True(IsNotFound(err))
+	var f testForeignErr
+	is.True(errors.As(err, &f))
+	is.Equal(f.msg, "registered")
+}
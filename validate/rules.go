@@ -0,0 +1,154 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	pkgerrs "github.com/pkg/errors"
+)
+
+// required is handled specially as it is the only rule that checks nil pointers.
+const required = "required"
+
+// builtins are the rules every Validator starts with.
+var builtins = map[string]Rule{ //nolint:gochecknoglobals // read only lookup table.
+	required: ruleRequired,
+	"min":    ruleMin,
+	"max":    ruleMax,
+	"email":  ruleEmail,
+	"oneof":  ruleOneOf,
+}
+
+// ruleRequired fails if v is nil, the zero value or an empty slice or map.
+func ruleRequired(v reflect.Value, _ string) (string, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "is required", nil
+		}
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return "is required", nil
+		}
+	default:
+		if v.IsZero() {
+			return "is required", nil
+		}
+	}
+	return "", nil
+}
+
+// ruleMin fails if v is less than param, strings are compared by their
+// length in characters and slices, arrays and maps by their length.
+func ruleMin(v reflect.Value, param string) (string, error) {
+	n, p, unit, err := size(v, param)
+	if err != nil {
+		return "", err
+	}
+	if n.less(p) {
+		return "must be at least " + param + unit, nil
+	}
+	return "", nil
+}
+
+// ruleMax fails if v is greater than param, see ruleMin.
+func ruleMax(v reflect.Value, param string) (string, error) {
+	n, p, unit, err := size(v, param)
+	if err != nil {
+		return "", err
+	}
+	if p.less(n) {
+		return "must be at most " + param + unit, nil
+	}
+	return "", nil
+}
+
+// ruleEmail fails if v is not a valid email address, empty strings are
+// allowed so should be combined with required.
+func ruleEmail(v reflect.Value, _ string) (string, error) {
+	if v.Kind() != reflect.String {
+		return "", pkgerrs.Errorf("email can't be used with %s", v.Type())
+	}
+	s := v.String()
+	if s == "" {
+		return "", nil
+	}
+	if a, err := mail.ParseAddress(s); err != nil || a.Address != s {
+		return "must be a valid email address", nil
+	}
+	return "", nil
+}
+
+// ruleOneOf fails if v is not one of the space separated values in param.
+func ruleOneOf(v reflect.Value, param string) (string, error) {
+	options := strings.Fields(param)
+	if len(options) == 0 {
+		return "", pkgerrs.New("oneof requires at least one value")
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return "", pkgerrs.Errorf("oneof can't be used with %s", v.Type())
+	}
+	s := fmt.Sprint(v)
+	for _, o := range options {
+		if s == o {
+			return "", nil
+		}
+	}
+	return "must be one of " + strings.Join(options, ", "), nil
+}
+
+// number holds a size as either an int, uint or float depending on the field.
+type number struct {
+	kind reflect.Kind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+func (n number) less(o number) bool {
+	switch n.kind {
+	case reflect.Uint:
+		return n.u < o.u
+	case reflect.Float64:
+		return n.f < o.f
+	default:
+		return n.i < o.i
+	}
+}
+
+// size returns the size of v compared by min and max along with param
+// parsed to the same kind, and the unit to add to messages.
+func size(v reflect.Value, param string) (n, p number, unit string, err error) {
+	switch v.Kind() {
+	case reflect.String:
+		n.i, unit = int64(utf8.RuneCountInString(v.String())), " characters"
+		p.i, err = strconv.ParseInt(param, 10, 64)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n.i, unit = int64(v.Len()), " items"
+		p.i, err = strconv.ParseInt(param, 10, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.i = v.Int()
+		p.i, err = strconv.ParseInt(param, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n.kind, p.kind = reflect.Uint, reflect.Uint
+		n.u = v.Uint()
+		p.u, err = strconv.ParseUint(param, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		n.kind, p.kind = reflect.Float64, reflect.Float64
+		n.f = v.Float()
+		p.f, err = strconv.ParseFloat(param, 64)
+	default:
+		return n, p, "", pkgerrs.Errorf("can't be used with %s", v.Type())
+	}
+	if err != nil {
+		return n, p, "", pkgerrs.Wrapf(err, "invalid param %q", param)
+	}
+	return n, p, unit, nil
+}
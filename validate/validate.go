@@ -0,0 +1,227 @@
+// Package validate checks request structs using validate struct tags and
+// returns an errs.ErrValidation, a BadRequest error, with a violation for
+// each field that failed.
+//
+//	type CreateUser struct {
+//		Name  string   `json:"name" validate:"required,min=3,max=64"`
+//		Email string   `json:"email" validate:"required,email"`
+//		Role  string   `json:"role" validate:"oneof=admin member"`
+//		Tags  []string `json:"tags" validate:"max=10"`
+//	}
+//
+//	if err := validate.Struct(req); err != nil {
+//		return err
+//	}
+//
+// Nested structs, slices and maps are walked and fields are identified by
+// JSON Pointers built from their json names, such as /addresses/0/city.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	pkgerrs "github.com/pkg/errors"
+
+	"github.com/theflyingcodr/lathos"
+	"github.com/theflyingcodr/lathos/errs"
+)
+
+// maxDepth stops the validator descending forever into cyclic structs.
+const maxDepth = 32
+
+// Rule checks the value of a field, param is the text after = in the tag,
+// ie 3 for min=3. A message such as "must be at least 3" is returned if the value
+// is invalid, an error is returned if the rule can't be applied, such as an
+// invalid param or an unsupported type.
+//
+// Pointers are dereferenced before a rule is called, nil pointers are only
+// checked by the required rule.
+type Rule func(v reflect.Value, param string) (msg string, err error)
+
+// Validator validates structs using their validate tags.
+type Validator struct {
+	code  string
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// New will create a Validator with the built-in rules, code is used
+// as the code of the errors it returns.
+func New(code string) *Validator {
+	v := &Validator{
+		code:  code,
+		rules: make(map[string]Rule, len(builtins)),
+	}
+	for name, r := range builtins {
+		v.rules[name] = r
+	}
+	return v
+}
+
+// RegisterRule adds a custom rule, or replaces an existing one, that can
+// then be used in validate tags by name.
+func (v *Validator) RegisterRule(name string, r Rule) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = r
+}
+
+// Struct will validate s, a struct or pointer to a struct. If any fields fail
+// an errs.ErrValidation is returned with a violation for each. An internal
+// error is returned if s isn't a struct or a tag is invalid.
+func (v *Validator) Struct(s interface{}) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errs.NewErrInternal(pkgerrs.Errorf("validate: expected a struct but got %T", s), v.code)
+	}
+	c := errs.NewCollector(v.code)
+	if err := v.validateStruct(c, rv, nil, 0); err != nil {
+		return errs.NewErrInternal(err, v.code)
+	}
+	return c.Err()
+}
+
+func (v *Validator) validateStruct(c *errs.Collector, rv reflect.Value, path []string, depth int) error {
+	if depth > maxDepth {
+		return nil
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		name := fieldName(sf)
+		fv := rv.Field(i)
+		fieldPath := path
+		if !sf.Anonymous || name != sf.Name {
+			fieldPath = append(path[:len(path):len(path)], name)
+		}
+		if err := v.validateField(c, fv, sf.Tag.Get("validate"), fieldPath); err != nil {
+			return pkgerrs.Wrapf(err, "field %s", sf.Name)
+		}
+		if err := v.descend(c, fv, fieldPath, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descend validates any structs held by rv.
+func (v *Validator) descend(c *errs.Collector, rv reflect.Value, path []string, depth int) error {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		return v.validateStruct(c, rv, path, depth)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := v.descend(c, rv.Index(i), append(path[:len(path):len(path)], fmt.Sprint(i)), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// keys are sorted so violations are in a consistent order.
+		keys := rv.MapKeys()
+		names := make(map[string]reflect.Value, len(keys))
+		sorted := make([]string, 0, len(keys))
+		for _, k := range keys {
+			name := fmt.Sprint(k)
+			names[name] = k
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			if err := v.descend(c, rv.MapIndex(names[name]), append(path[:len(path):len(path)], name), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateField applies each rule in tag to rv, adding a violation for each that fails.
+func (v *Validator) validateField(c *errs.Collector, rv reflect.Value, tag string, path []string) error {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	field := lathos.JSONPointer(path...)
+	label := field
+	if len(path) > 0 {
+		label = path[len(path)-1]
+	}
+	for _, spec := range strings.Split(tag, ",") {
+		name, param := spec, ""
+		if i := strings.Index(spec, "="); i >= 0 {
+			name, param = spec[:i], spec[i+1:]
+		}
+		v.mu.RLock()
+		rule, ok := v.rules[name]
+		v.mu.RUnlock()
+		if !ok {
+			return pkgerrs.Errorf("unknown validation rule %q", name)
+		}
+		val, isNil := deref(rv)
+		if isNil && name != required {
+			continue
+		}
+		msg, err := rule(val, param)
+		if err != nil {
+			return pkgerrs.Wrapf(err, "rule %s", name)
+		}
+		if msg != "" {
+			c.AddViolation(lathos.Violation{Field: field, Rule: name, Message: label + " " + msg})
+		}
+	}
+	return nil
+}
+
+// deref returns the value pointed to by rv, isNil is true if
+// a nil pointer or interface is found.
+func deref(rv reflect.Value) (v reflect.Value, isNil bool) {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, true
+		}
+		rv = rv.Elem()
+	}
+	return rv, false
+}
+
+// fieldName returns the name of the field when encoded as JSON, the Go
+// name is used if it has no json name or isn't encoded, ie json:"-", as
+// its validate tag is still enforced.
+func fieldName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return sf.Name
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+	return sf.Name
+}
+
+// defaultValidator is used by the package level functions.
+var defaultValidator = New("") //nolint:gochecknoglobals // shared default validator.
+
+// RegisterRule adds a custom rule to the default Validator.
+func RegisterRule(name string, r Rule) {
+	defaultValidator.RegisterRule(name, r)
+}
+
+// Struct will validate s using the default Validator.
+func Struct(s interface{}) error {
+	return defaultValidator.Struct(s)
+}
@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/theflyingcodr/lathos"
+)
+
+type testAddress struct {
+	City     string `json:"city" validate:"required"`
+	Postcode string `json:"postcode,omitempty" validate:"max=8"`
+}
+
+type testAudit struct {
+	CreatedBy string `validate:"required"`
+}
+
+type testRequest struct {
+	testAudit
+	Name      string                 `json:"name" validate:"required,min=3,max=8"`
+	Email     string                 `json:"email" validate:"email"`
+	Role      string                 `json:"role" validate:"oneof=admin member"`
+	Age       *int                   `json:"age" validate:"required,min=18"`
+	Score     float64                `json:"score" validate:"max=9.5"`
+	Tags      []string               `json:"tags" validate:"max=2"`
+	Address   *testAddress           `json:"address"`
+	Addresses []testAddress          `json:"addresses"`
+	Labels    map[string]testAddress `json:"labels"`
+	Secret    string                 `json:"-" validate:"required"`
+	ignored   string                 //nolint:unused // tests unexported fields are skipped.
+}
+
+func TestValidator_Struct(t *testing.T) {
+	t.Parallel()
+	age := func(i int) *int { return &i }
+	valid := func() testRequest {
+		return testRequest{
+			testAudit: testAudit{CreatedBy: "me"},
+			Name:      "alice",
+			Email:     "alice@example.com",
+			Role:      "admin",
+			Age:       age(30),
+			Address:   &testAddress{City: "Leeds"},
+			Secret:    "s3cret",
+		}
+	}
+	tests := map[string]struct {
+		req func() interface{}
+		exp []lathos.Violation
+	}{
+		"valid request should pass": {
+			req: func() interface{} { r := valid(); return &r },
+		},
+		"each failing field should be a violation": {
+			req: func() interface{} {
+				r := valid()
+				r.CreatedBy = ""
+				r.Name = "al"
+				r.Email = "not an email"
+				r.Role = "owner"
+				r.Age = nil
+				r.Score = 10
+				r.Tags = []string{"a", "b", "c"}
+				r.Secret = ""
+				return r
+			},
+			exp: []lathos.Violation{
+				{Field: "/CreatedBy", Rule: "required", Message: "CreatedBy is required"},
+				{Field: "/name", Rule: "min", Message: "name must be at least 3 characters"},
+				{Field: "/email", Rule: "email", Message: "email must be a valid email address"},
+				{Field: "/role", Rule: "oneof", Message: "role must be one of admin, member"},
+				{Field: "/age", Rule: "required", Message: "age is required"},
+				{Field: "/score", Rule: "max", Message: "score must be at most 9.5"},
+				{Field: "/tags", Rule: "max", Message: "tags must be at most 2 items"},
+				{Field: "/Secret", Rule: "required", Message: "Secret is required"},
+			},
+		},
+		"pointer value should be checked": {
+			req: func() interface{} { r := valid(); r.Age = age(17); return r },
+			exp: []lathos.Violation{{Field: "/age", Rule: "min", Message: "age must be at least 18"}},
+		},
+		"nested structs, slices and maps should be walked": {
+			req: func() interface{} {
+				r := valid()
+				r.Address.Postcode = "123456789"
+				r.Addresses = []testAddress{{City: "York"}, {}}
+				r.Labels = map[string]testAddress{"work": {}, "home": {}}
+				return r
+			},
+			exp: []lathos.Violation{
+				{Field: "/address/postcode", Rule: "max", Message: "postcode must be at most 8 characters"},
+				{Field: "/addresses/1/city", Rule: "required", Message: "city is required"},
+				{Field: "/labels/home/city", Rule: "required", Message: "city is required"},
+				{Field: "/labels/work/city", Rule: "required", Message: "city is required"},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := Struct(test.req())
+			if test.exp == nil {
+				is.NoErr(err)
+				return
+			}
+			is.True(lathos.IsBadRequest(err))
+			is.Equal(lathos.ViolationsOf(err), test.exp)
+		})
+	}
+}
+
+func TestValidator_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		in  interface{}
+		err string
+	}{
+		"non struct should error": {
+			in:  "name",
+			err: "expected a struct",
+		},
+		"unknown rule should error": {
+			in: struct {
+				Name string `validate:"uppercase"`
+			}{},
+			err: `unknown validation rule "uppercase"`,
+		},
+		"invalid param should error": {
+			in: struct {
+				Name string `validate:"min=three"`
+			}{},
+			err: `invalid param "three"`,
+		},
+		"unsupported type should error": {
+			in: struct {
+				Name bool `validate:"email"`
+			}{},
+			err: "email can't be used with bool",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			err := New("V001").Struct(test.in)
+			is.True(lathos.IsInternalError(err))
+			is.True(strings.Contains(err.Error(), test.err))
+		})
+	}
+}
+
+func TestValidator_RegisterRule(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	v := New("V001")
+	v.RegisterRule("uppercase", func(v reflect.Value, _ string) (string, error) {
+		if v.Kind() != reflect.String {
+			return "", errors.New("uppercase can only be used with strings")
+		}
+		if strings.ToUpper(v.String()) != v.String() {
+			return "must be upper case", nil
+		}
+		return "", nil
+	})
+	req := struct {
+		Code string `json:"code" validate:"required,uppercase"`
+	}{Code: "abc"}
+	err := v.Struct(req)
+	is.Equal(lathos.ViolationsOf(err), []lathos.Violation{{Field: "/code", Rule: "uppercase", Message: "code must be upper case"}})
+	is.True(errors.Is(err, lathos.CodeMatcher("V001")))
+}
@@ -0,0 +1,51 @@
+package lathos
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Violation describes a single field that failed validation.
+type Violation struct {
+	// Field is the path to the field, either a JSON Pointer such as /address/0/city
+	// or a dotted path such as address.0.city.
+	Field string `json:"field"`
+	// Rule is the validation rule that failed, such as required or max.
+	Rule string `json:"rule,omitempty"`
+	// Message is a human readable explanation of the violation.
+	Message string `json:"message"`
+	// Code is an optional error code for the violation.
+	Code string `json:"code,omitempty"`
+}
+
+// Violations when implemented will return each field that failed validation,
+// allowing a client to highlight them rather than only show a single detail.
+type Violations interface {
+	Violations() []Violation
+}
+
+// ViolationsOf returns the violations of the first Violations error in the chain,
+// nil is returned if there isn't one.
+func ViolationsOf(err error) []Violation {
+	var t Violations
+	if !errors.As(err, &t) {
+		return nil
+	}
+	return t.Violations()
+}
+
+// JSONPointer will build a RFC 6901 JSON Pointer from tokens, escaping
+// any ~ and / characters:
+//
+//	lathos.JSONPointer("address", "0", "city") // /address/0/city
+func JSONPointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(t))
+	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1") //nolint:gochecknoglobals // read only replacer.
@@ -0,0 +1,68 @@
+package lathos
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type testViolationsErr struct {
+	testClientErr
+	violations []Violation
+}
+
+func (t testViolationsErr) Violations() []Violation {
+	return t.violations
+}
+
+func TestViolationsOf(t *testing.T) {
+	t.Parallel()
+	vv := []Violation{{Field: "/name", Rule: "required", Message: "name is required"}}
+	tests := map[string]struct {
+		err error
+		exp []Violation
+	}{
+		"wrapped violations should be returned": {
+			err: fmt.Errorf("validate: %w", testViolationsErr{testClientErr: testClientErr{errors.New("invalid")}, violations: vv}),
+			exp: vv,
+		},
+		"error without violations should return nil": {
+			err: &testClientErr{},
+		},
+		"nil error should return nil": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, ViolationsOf(test.err))
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		tokens []string
+		exp    string
+	}{
+		"no tokens should be the root": {
+			exp: "",
+		},
+		"tokens should be joined": {
+			tokens: []string{"address", "0", "city"},
+			exp:    "/address/0/city",
+		},
+		"special characters should be escaped": {
+			tokens: []string{"a/b", "m~n"},
+			exp:    "/a~1b/m~0n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			is.Equal(test.exp, JSONPointer(test.tokens...))
+		})
+	}
+}
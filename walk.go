@@ -0,0 +1,132 @@
+package lathos
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxWalkDepth stops Walk descending forever into an error tree
+// containing a cycle it cannot detect.
+const maxWalkDepth = 100
+
+// WalkFunc is called by Walk for each error in a tree along with its
+// depth, the root error has a depth of 0.
+// Returning false will stop the walk.
+type WalkFunc func(err error, depth int) bool
+
+// Walk will visit every error in the tree rooted at err, depth first, calling fn for each.
+// Both Unwrap() error and Unwrap() []error are followed so errors created with
+// errors.Join or fmt.Errorf with multiple %w verbs are fully visited.
+//
+// An error that has already been visited will not be visited again, protecting
+// against cycles.
+func Walk(err error, fn WalkFunc) {
+	walk(err, 0, map[visitKey]struct{}{}, fn)
+}
+
+// visitKey identifies a pointer error, value errors can't be reliably
+// compared so are protected against by maxWalkDepth.
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func walk(err error, depth int, seen map[visitKey]struct{}, fn WalkFunc) bool {
+	if err == nil || depth > maxWalkDepth {
+		return true
+	}
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr {
+		k := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if _, ok := seen[k]; ok {
+			return true
+		}
+		seen[k] = struct{}{}
+	}
+	if !fn(err, depth) {
+		return false
+	}
+	if isNilPtr(err) {
+		return true
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(e.Unwrap(), depth+1, seen, fn)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			if !walk(child, depth+1, seen, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// behaviour pairs a behaviour name with a check for it being implemented
+// directly by an error rather than by something it wraps.
+type behaviour struct {
+	name       string
+	implements func(err error) bool
+}
+
+// behaviours lists every behaviour reported by Explain.
+var behaviours = []behaviour{ //nolint:gochecknoglobals // read only lookup table.
+	{"ClientError", func(err error) bool { _, ok := err.(ClientError); return ok }},
+	{"InternalError", func(err error) bool { _, ok := err.(InternalError); return ok }},
+	{"NotFound", func(err error) bool { _, ok := err.(NotFound); return ok }},
+	{"Duplicate", func(err error) bool { _, ok := err.(Duplicate); return ok }},
+	{"NotAuthorised", func(err error) bool { _, ok := err.(NotAuthorised); return ok }},
+	{"NotAuthenticated", func(err error) bool { _, ok := err.(NotAuthenticated); return ok }},
+	{"BadRequest", func(err error) bool { _, ok := err.(BadRequest); return ok }},
+	{"CannotProcess", func(err error) bool { _, ok := err.(CannotProcess); return ok }},
+	{"Unavailable", func(err error) bool { _, ok := err.(Unavailable); return ok }},
+	{"Retryable", func(err error) bool { _, ok := err.(Retryable); return ok }},
+	{"RetryAfter", func(err error) bool { _, ok := err.(RetryAfter); return ok }},
+	{"TooManyRequests", func(err error) bool { _, ok := err.(TooManyRequests); return ok }},
+	{"Timeout", func(err error) bool { _, ok := err.(Timeout); return ok }},
+	{"Canceled", func(err error) bool { _, ok := err.(Canceled); return ok }},
+	{"Conflict", func(err error) bool { _, ok := err.(Conflict); return ok }},
+	{"Severity", func(err error) bool { _, ok := err.(Severity); return ok }},
+	{"Namespaced", func(err error) bool { _, ok := err.(Namespaced); return ok }},
+	{"Violations", func(err error) bool { _, ok := err.(Violations); return ok }},
+}
+
+// Explain will return a human readable dump of an error tree, each error is printed
+// on its own line, indented by its depth, with its concrete type and the lathos
+// behaviours it implements itself, for example:
+//
+//	*errors.withStack: get user: Not found: user 123 not found
+//	  *fmt.wrapError: get user: Not found: user 123 not found
+//	    errs.ErrNotFound [ClientError NotFound]: Not found: user 123 not found
+//
+// This can be used when debugging to find out which layer introduced a behaviour.
+func Explain(err error) string {
+	var sb strings.Builder
+	Walk(err, func(err error, depth int) bool {
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(fmt.Sprintf("%T", err))
+		if isNilPtr(err) {
+			sb.WriteString(": <nil>\n")
+			return true
+		}
+		var names []string
+		for _, b := range behaviours {
+			if b.implements(err) {
+				names = append(names, b.name)
+			}
+		}
+		if len(names) > 0 {
+			sb.WriteString(" [" + strings.Join(names, " ") + "]")
+		}
+		sb.WriteString(": " + err.Error() + "\n")
+		return true
+	})
+	return sb.String()
+}
+
+// isNilPtr returns true if err is a typed nil pointer, such as (*myErr)(nil),
+// calling its methods could panic.
+func isNilPtr(err error) bool {
+	v := reflect.ValueOf(err)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
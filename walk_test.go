@@ -0,0 +1,114 @@
+package lathos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	pkgerrs "github.com/pkg/errors"
+)
+
+// testCyclicErr unwraps to whatever next points at, allowing cycles.
+type testCyclicErr struct {
+	next error
+}
+
+func (t *testCyclicErr) Error() string { return "cyclic" }
+func (t *testCyclicErr) Unwrap() error { return t.next }
+
+type testNamedNotFound struct {
+	testNotFound
+}
+
+func (t testNamedNotFound) Error() string { return "not found" }
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	cyclic := &testCyclicErr{}
+	cyclic.next = &testCyclicErr{next: cyclic}
+	std := errors.New("standard error")
+	tests := map[string]struct {
+		err       error
+		expDepths []int
+	}{
+		"nil error should not be visited": {
+			err:       nil,
+			expDepths: nil,
+		}, "single error should be visited once": {
+			err:       std,
+			expDepths: []int{0},
+		}, "wrapped errors should be visited with depth": {
+			err:       pkgerrs.Wrap(fmt.Errorf("my error %w", std), "wrapped"),
+			expDepths: []int{0, 1, 2, 3},
+		}, "joined errors should all be visited": {
+			err:       errors.Join(fmt.Errorf("a %w", std), errors.New("b")),
+			expDepths: []int{0, 1, 2, 1},
+		}, "cycles should be visited once": {
+			err:       cyclic,
+			expDepths: []int{0, 1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.NewRelaxed(t)
+			var depths []int
+			Walk(test.err, func(err error, depth int) bool {
+				depths = append(depths, depth)
+				return true
+			})
+			is.Equal(test.expDepths, depths)
+		})
+	}
+}
+
+func TestWalk_Stop(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	var visited int
+	Walk(errors.Join(errors.New("a"), errors.New("b")), func(err error, depth int) bool {
+		visited++
+		return depth == 0
+	})
+	is.Equal(visited, 2)
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	out := Explain(fmt.Errorf("get user: %w", testNamedNotFound{}))
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	is.Equal(len(lines), 2)
+	is.Equal(lines[0], "*fmt.wrapError: get user: not found")
+	is.Equal(lines[1], "  lathos.testNamedNotFound [ClientError NotFound]: not found")
+}
+
+// testPtrErr dereferences its receiver so panics if nil.
+type testPtrErr struct {
+	msg string
+}
+
+func (t *testPtrErr) Error() string {
+	return t.msg
+}
+
+func (t *testPtrErr) Unwrap() error {
+	return errors.New(t.msg)
+}
+
+// testFixedWrap wraps err without calling its Error method.
+type testFixedWrap struct {
+	err error
+}
+
+func (t testFixedWrap) Error() string { return "wrapped" }
+func (t testFixedWrap) Unwrap() error { return t.err }
+
+func TestExplain_TypedNil(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+	var nilErr *testPtrErr
+	is.Equal(Explain(nilErr), "*lathos.testPtrErr: <nil>\n")
+	is.Equal(Explain(testFixedWrap{nilErr}), "lathos.testFixedWrap: wrapped\n  *lathos.testPtrErr: <nil>\n")
+}